@@ -21,6 +21,26 @@ func SupplyAsyncWithExecutor[T any](executor pool.Executor, supplier func() T) *
 }
 
 func SupplyAsyncCtxWithExecutor[T any](ctx context.Context, executor pool.Executor, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(ctx, executor, supplier, false)
+}
+
+// TrySupplyAsync 非阻塞提交：执行器饱和时不阻塞调用方，Future 直接以 pool.ErrRejected 失败
+// 仅对实现了 pool.ExecutorService 的执行器生效，其他执行器退化为 SupplyAsyncWithExecutor
+func TrySupplyAsync[T any](executor pool.Executor, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(context.Background(), executor, supplier, true)
+}
+
+func TrySupplyAsyncCtx[T any](ctx context.Context, executor pool.Executor, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(ctx, executor, supplier, true)
+}
+
+func supplyAsync[T any](ctx context.Context, executor pool.Executor, supplier func() T, try bool) *CompletableFuture[T] {
+	// 显式指定的执行器同时作为派生阶段的默认执行器
+	return supplyAsyncOn(ctx, executor, executor, supplier, try)
+}
+
+// supplyAsyncOn 将 supplier 提交到 submitTo，派生阶段的默认执行器为 executor
+func supplyAsyncOn[T any](ctx context.Context, executor, submitTo pool.Executor, supplier func() T, try bool) *CompletableFuture[T] {
 	// 自动创建，无需 Pool 复用逻辑
 	f := NewWithContext[T](ctx)
 	if supplier == nil {
@@ -28,14 +48,16 @@ func SupplyAsyncCtxWithExecutor[T any](ctx context.Context, executor pool.Execut
 		return f
 	}
 
-	exec := executor
+	f.executor = executor
+	exec := submitTo
 	if exec == nil {
-		exec = pool.GlobalExecutor
+		exec = f.DefaultExecutor()
 	}
 
-	exec.Submit(func() {
-		if ctx.Err() != nil {
-			f.CompleteExceptionally(ctx.Err())
+	stop := startGuard(f)
+	submit(f, exec, func() {
+		// Future 已被取消、超时或 context 已结束，不再执行任务
+		if !started(f, stop) {
 			return
 		}
 		val, err := safecall(func() T { return supplier() })
@@ -44,10 +66,42 @@ func SupplyAsyncCtxWithExecutor[T any](ctx context.Context, executor pool.Execut
 		} else {
 			f.Complete(val)
 		}
-	})
+	}, try)
 	return f
 }
 
+// SupplyAsyncWithPriority 以指定优先级提交任务，配合 pool.PriorityExecutor 使用
+// 优先级记录在 Future 的 context 中，由它派生的异步阶段（ThenApplyAsync 等）继承同一优先级
+func SupplyAsyncWithPriority[T any](executor pool.Executor, priority int, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(pool.WithPriority(context.Background(), priority), executor, supplier, false)
+}
+
+// SupplyAsyncKeyed 在 key 上串行执行 supplier，同一 key 的任务按提交顺序执行
+// 未指定执行器的后续异步阶段默认同样在该 key 上执行
+func SupplyAsyncKeyed[T any](executor *pool.KeyedExecutor, key any, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(context.Background(), executor.ForKey(key), supplier, false)
+}
+
+// SupplyAsyncTenant 以租户 tenant 的身份在公平调度执行器上执行 supplier
+// 未指定执行器的后续异步阶段默认同样归属该租户
+func SupplyAsyncTenant[T any](executor *pool.FairShareExecutor, tenant any, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(context.Background(), executor.ForTenant(tenant), supplier, false)
+}
+
+// SupplyAsyncWeighted 以权重 weight 提交 supplier，占用执行器 weight 份容量（见 pool.WeightedExecutor）
+// 只有 supplier 本身带权重，未指定执行器的后续异步阶段默认以权重 1 提交到 executor，需要时显式传入 pool.Weighted 视图。
+// executor 不支持权重且 weight > 1 时 Future 以 pool.ErrWeightUnsupported 失败
+func SupplyAsyncWeighted[T any](executor pool.Executor, weight int, supplier func() T) *CompletableFuture[T] {
+	return supplyAsyncOn(context.Background(), executor, pool.Weighted(executor, weight), supplier, false)
+}
+
+// SupplyAsyncPinned 在锁定 OS 线程的执行器中选一个 worker（pool.LockedThreadExecutor.Pin）执行 supplier
+// 未指定执行器的后续异步阶段默认同样在该 worker 的线程上执行；同步阶段（如 ThenApply）在完成上游的 goroutine 上执行，
+// 上游已完成时则在调用方执行，需要线程亲和的阶段应使用 *Async 变体
+func SupplyAsyncPinned[T any](executor *pool.LockedThreadExecutor, supplier func() T) *CompletableFuture[T] {
+	return supplyAsync(context.Background(), executor.Pin(), supplier, false)
+}
+
 // ============ RunAsync (无返回值) ============
 
 func RunAsync(runnable func()) *CompletableFuture[struct{}] {
@@ -63,20 +117,64 @@ func RunAsyncWithExecutor(executor pool.Executor, runnable func()) *CompletableF
 }
 
 func RunAsyncCtxWithExecutor(ctx context.Context, executor pool.Executor, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(ctx, executor, runnable, false)
+}
+
+// TryRunAsync 非阻塞提交，语义同 TrySupplyAsync
+func TryRunAsync(executor pool.Executor, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(context.Background(), executor, runnable, true)
+}
+
+func TryRunAsyncCtx(ctx context.Context, executor pool.Executor, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(ctx, executor, runnable, true)
+}
+
+// RunAsyncWithPriority 以指定优先级提交任务，语义同 SupplyAsyncWithPriority
+func RunAsyncWithPriority(executor pool.Executor, priority int, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(pool.WithPriority(context.Background(), priority), executor, runnable, false)
+}
+
+// RunAsyncKeyed 在 key 上串行执行 runnable，语义同 SupplyAsyncKeyed
+func RunAsyncKeyed(executor *pool.KeyedExecutor, key any, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(context.Background(), executor.ForKey(key), runnable, false)
+}
+
+// RunAsyncTenant 以租户 tenant 的身份执行 runnable，语义同 SupplyAsyncTenant
+func RunAsyncTenant(executor *pool.FairShareExecutor, tenant any, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(context.Background(), executor.ForTenant(tenant), runnable, false)
+}
+
+// RunAsyncWeighted 以权重 weight 提交 runnable，语义同 SupplyAsyncWeighted
+func RunAsyncWeighted(executor pool.Executor, weight int, runnable func()) *CompletableFuture[struct{}] {
+	return runAsyncOn(context.Background(), executor, pool.Weighted(executor, weight), runnable, false)
+}
+
+// RunAsyncPinned 在锁定 OS 线程的执行器中选一个 worker 执行 runnable，语义同 SupplyAsyncPinned
+func RunAsyncPinned(executor *pool.LockedThreadExecutor, runnable func()) *CompletableFuture[struct{}] {
+	return runAsync(context.Background(), executor.Pin(), runnable, false)
+}
+
+func runAsync(ctx context.Context, executor pool.Executor, runnable func(), try bool) *CompletableFuture[struct{}] {
+	return runAsyncOn(ctx, executor, executor, runnable, try)
+}
+
+// runAsyncOn 语义同 supplyAsyncOn
+func runAsyncOn(ctx context.Context, executor, submitTo pool.Executor, runnable func(), try bool) *CompletableFuture[struct{}] {
 	f := NewWithContext[struct{}](ctx)
 	if runnable == nil {
 		f.CompleteExceptionally(ErrNilFunction)
 		return f
 	}
 
-	exec := executor
+	f.executor = executor
+	exec := submitTo
 	if exec == nil {
-		exec = pool.GlobalExecutor
+		exec = f.DefaultExecutor()
 	}
 
-	exec.Submit(func() {
-		if ctx.Err() != nil {
-			f.CompleteExceptionally(ctx.Err())
+	stop := startGuard(f)
+	submit(f, exec, func() {
+		if !started(f, stop) {
 			return
 		}
 		_, err := safecall(func() int {
@@ -88,10 +186,80 @@ func RunAsyncCtxWithExecutor(ctx context.Context, executor pool.Executor, runnab
 		} else {
 			f.Complete(struct{}{})
 		}
-	})
+	}, try)
 	return f
 }
 
+// submit 提交任务，执行器支持 context 时传入 taskContext，
+// 使执行器能够在任务开始前丢弃已完成（取消、超时）的 Future 对应的任务，并在任务被拒绝或丢弃时让 Future 失败而不是永远挂起。
+// try 为 true 时使用非阻塞的 TrySubmit
+func submit[T any](f *CompletableFuture[T], exec pool.Executor, task pool.Runnable, try bool) {
+	ce, ok := exec.(pool.ContextExecutor)
+	if !ok {
+		exec.Submit(task)
+		return
+	}
+
+	ctx := &taskContext[T]{Context: f.ctx, f: f}
+	var err error
+	if es, ok := exec.(pool.ExecutorService); ok && try {
+		err = es.TrySubmit(ctx, task)
+	} else {
+		err = ce.SubmitCtx(ctx, task)
+	}
+	if err != nil {
+		f.CompleteExceptionally(err)
+	}
+}
+
+// taskContext 提交给执行器的 context，Future 完成即视为结束
+// Deadline / Value 来自 Future 自身的 context；父 context 结束时 startGuard 会完成 Future，因此同样可以感知。
+// 同时实现 pool.RejectHandler，任务被丢弃时让 Future 失败。
+// 相比 context.WithCancel + WithValue 只需一次分配
+type taskContext[T any] struct {
+	context.Context
+	f *CompletableFuture[T]
+}
+
+func (c *taskContext[T]) Done() <-chan struct{} {
+	return c.f.getDoneChanLazy()
+}
+
+func (c *taskContext[T]) Err() error {
+	if !c.f.IsDone() {
+		return nil
+	}
+	if err := c.Context.Err(); err != nil {
+		return err
+	}
+	return context.Canceled
+}
+
+func (c *taskContext[T]) OnRejected(err error) {
+	c.f.CompleteExceptionally(err)
+}
+
+// startGuard 在任务排队期间监听 Future 的 context，context 结束时立即以 ctx.Err() 完成 Future，
+// 即使执行器丢弃了任务也不会让 Future 永远挂起。
+// 返回停止监听的函数，交给任务开始时调用的 started；context 不会结束时返回 nil，不做任何分配
+func startGuard[T any](f *CompletableFuture[T]) func() bool {
+	if f.ctx.Done() == nil {
+		return nil
+	}
+	return context.AfterFunc(f.ctx, func() {
+		f.CompleteExceptionally(f.ctx.Err())
+	})
+}
+
+// started 在任务开始时停止监听，返回 false 表示任务不应再执行；之后 context 结束不影响任务结果
+func started[T any](f *CompletableFuture[T], stop func() bool) bool {
+	// stop 返回 false 说明 context 已经结束，Future 已由 AfterFunc 完成
+	if stop != nil && !stop() {
+		return false
+	}
+	return !f.IsDone()
+}
+
 func CompletedFuture[T any](val T) *CompletableFuture[T] {
 	f := New[T]()
 	f.Complete(val)
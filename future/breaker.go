@@ -0,0 +1,360 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xigexb/go-future/pool"
+)
+
+// ErrCircuitOpen 熔断器处于打开状态（或半开状态试探名额已满）时返回
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState 熔断器状态
+type CircuitState int32
+
+const (
+	StateClosed CircuitState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig 熔断器配置，零值字段使用默认值
+type CircuitBreakerConfig struct {
+	// WindowSize 滑动窗口大小（按最近 N 次调用统计），默认 100
+	WindowSize int
+	// MinimumCalls 窗口内至少有多少次调用才计算失败率，默认 10
+	MinimumCalls int
+	// FailureRateThreshold 失败率阈值 (0, 1]，达到后打开熔断器，默认 0.5
+	FailureRateThreshold float64
+	// SlowCallDuration 调用耗时达到该值即视为慢调用，0 表示不统计慢调用
+	SlowCallDuration time.Duration
+	// SlowCallRateThreshold 慢调用比例阈值 (0, 1]，达到后打开熔断器，默认 1
+	SlowCallRateThreshold float64
+	// OpenDuration 打开状态持续多久后进入半开状态，默认 30s
+	OpenDuration time.Duration
+	// HalfOpenMaxCalls 半开状态允许的试探调用数，全部成功则关闭，任一失败或慢调用则重新打开，默认 1
+	HalfOpenMaxCalls int
+	// IsFailure 判断错误是否计为失败，nil 表示所有错误都计为失败
+	IsFailure func(error) bool
+}
+
+// CircuitBreaker 熔断器
+// 关闭状态下统计滑动窗口内的失败率与慢调用率，超过阈值后打开；
+// 打开状态下直接返回以 ErrCircuitOpen 失败的 Future，不再提交任务
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mu         sync.Mutex
+	state      CircuitState
+	generation uint64
+	openedAt   time.Time
+
+	// 滑动窗口 (环形缓冲)
+	window   []callOutcome
+	pos      int
+	count    int
+	failures int
+	slows    int
+
+	// 半开状态计数
+	halfOpenInFlight  int
+	halfOpenSuccesses int
+
+	listeners []func(from, to CircuitState)
+}
+
+type callOutcome uint8
+
+const (
+	outcomeFailure callOutcome = 1 << iota
+	outcomeSlow
+)
+
+// NewCircuitBreaker 创建熔断器
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = 100
+	}
+	if cfg.MinimumCalls <= 0 {
+		cfg.MinimumCalls = 10
+	}
+	if cfg.MinimumCalls > cfg.WindowSize {
+		cfg.MinimumCalls = cfg.WindowSize
+	}
+	if cfg.FailureRateThreshold <= 0 || cfg.FailureRateThreshold > 1 {
+		cfg.FailureRateThreshold = 0.5
+	}
+	if cfg.SlowCallRateThreshold <= 0 || cfg.SlowCallRateThreshold > 1 {
+		cfg.SlowCallRateThreshold = 1
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+	return &CircuitBreaker{
+		cfg:    cfg,
+		state:  StateClosed,
+		window: make([]callOutcome, cfg.WindowSize),
+	}
+}
+
+// State 返回当前状态（打开状态到期后会返回半开）
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	notify := cb.refreshLocked()
+	state := cb.state
+	cb.mu.Unlock()
+	notify()
+	return state
+}
+
+// OnStateChange 注册状态变化监听器，监听器在状态变化的 goroutine 中同步调用
+func (cb *CircuitBreaker) OnStateChange(fn func(from, to CircuitState)) {
+	if fn == nil {
+		return
+	}
+	cb.mu.Lock()
+	cb.listeners = append(cb.listeners, fn)
+	cb.mu.Unlock()
+}
+
+// Reset 强制回到关闭状态并清空统计
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	notify := cb.transitionLocked(StateClosed)
+	cb.mu.Unlock()
+	notify()
+}
+
+// acquire 申请一次调用许可，返回许可所属的代数
+func (cb *CircuitBreaker) acquire() (uint64, error) {
+	cb.mu.Lock()
+	notify := cb.refreshLocked()
+	defer func() {
+		cb.mu.Unlock()
+		notify()
+	}()
+
+	switch cb.state {
+	case StateOpen:
+		return 0, ErrCircuitOpen
+	case StateHalfOpen:
+		if cb.halfOpenInFlight+cb.halfOpenSuccesses >= cb.cfg.HalfOpenMaxCalls {
+			return 0, ErrCircuitOpen
+		}
+		cb.halfOpenInFlight++
+	}
+	return cb.generation, nil
+}
+
+// release 归还许可但不记录结果，用于调用方取消等不能反映下游状况的情况
+func (cb *CircuitBreaker) release(generation uint64) {
+	cb.mu.Lock()
+	if generation == cb.generation && cb.state == StateHalfOpen {
+		cb.halfOpenInFlight--
+	}
+	cb.mu.Unlock()
+}
+
+// callerCanceled 判断错误是否来自调用方取消，这类结果不计入熔断统计
+func callerCanceled(err error) bool {
+	return errors.Is(err, ErrCanceled) || errors.Is(err, context.Canceled)
+}
+
+// record 记录一次调用结果
+func (cb *CircuitBreaker) record(generation uint64, err error, elapsed time.Duration) {
+	var outcome callOutcome
+	if err != nil && (cb.cfg.IsFailure == nil || cb.cfg.IsFailure(err)) {
+		outcome |= outcomeFailure
+	}
+	if cb.cfg.SlowCallDuration > 0 && elapsed >= cb.cfg.SlowCallDuration {
+		outcome |= outcomeSlow
+	}
+
+	cb.mu.Lock()
+	notify := func() {}
+	defer func() {
+		cb.mu.Unlock()
+		notify()
+	}()
+
+	// 状态已经变化，旧许可的结果不再计入
+	if generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case StateHalfOpen:
+		cb.halfOpenInFlight--
+		if outcome != 0 {
+			notify = cb.transitionLocked(StateOpen)
+			return
+		}
+		cb.halfOpenSuccesses++
+		if cb.halfOpenSuccesses >= cb.cfg.HalfOpenMaxCalls {
+			notify = cb.transitionLocked(StateClosed)
+		}
+	case StateClosed:
+		if cb.count == len(cb.window) {
+			old := cb.window[cb.pos]
+			if old&outcomeFailure != 0 {
+				cb.failures--
+			}
+			if old&outcomeSlow != 0 {
+				cb.slows--
+			}
+		} else {
+			cb.count++
+		}
+		cb.window[cb.pos] = outcome
+		cb.pos = (cb.pos + 1) % len(cb.window)
+		if outcome&outcomeFailure != 0 {
+			cb.failures++
+		}
+		if outcome&outcomeSlow != 0 {
+			cb.slows++
+		}
+
+		if cb.count >= cb.cfg.MinimumCalls {
+			total := float64(cb.count)
+			if float64(cb.failures)/total >= cb.cfg.FailureRateThreshold ||
+				(cb.cfg.SlowCallDuration > 0 && float64(cb.slows)/total >= cb.cfg.SlowCallRateThreshold) {
+				notify = cb.transitionLocked(StateOpen)
+			}
+		}
+	}
+}
+
+// refreshLocked 打开状态到期后切换到半开
+func (cb *CircuitBreaker) refreshLocked() func() {
+	if cb.state == StateOpen && time.Since(cb.openedAt) >= cb.cfg.OpenDuration {
+		return cb.transitionLocked(StateHalfOpen)
+	}
+	return func() {}
+}
+
+// transitionLocked 切换状态并重置统计，返回在解锁后调用的通知函数
+func (cb *CircuitBreaker) transitionLocked(to CircuitState) func() {
+	from := cb.state
+	cb.state = to
+	cb.generation++
+	cb.pos, cb.count, cb.failures, cb.slows = 0, 0, 0, 0
+	cb.halfOpenInFlight, cb.halfOpenSuccesses = 0, 0
+	if to == StateOpen {
+		cb.openedAt = time.Now()
+	}
+	if from == to || len(cb.listeners) == 0 {
+		return func() {}
+	}
+	listeners := append([]func(from, to CircuitState){}, cb.listeners...)
+	return func() {
+		for _, l := range listeners {
+			l(from, to)
+		}
+	}
+}
+
+// ============ 包装异步任务 ============
+
+// SupplyAsyncWithBreaker 通过熔断器提交任务，熔断器打开时直接返回以 ErrCircuitOpen 失败的 Future
+func SupplyAsyncWithBreaker[T any](cb *CircuitBreaker, supplier func() T) *CompletableFuture[T] {
+	return SupplyAsyncCtxWithBreaker(context.Background(), cb, nil, supplier)
+}
+
+// SupplyAsyncCtxWithBreaker 耗时从任务开始执行时计算，不包含排队时间
+// 未开始执行的任务与被调用方取消的任务只归还许可，不计入统计；取消返回的阶段同时取消任务
+func SupplyAsyncCtxWithBreaker[T any](ctx context.Context, cb *CircuitBreaker, executor pool.Executor, supplier func() T) *CompletableFuture[T] {
+	if supplier == nil {
+		return FailedFuture[T](ErrNilFunction)
+	}
+	generation, err := cb.acquire()
+	if err != nil {
+		return FailedFuture[T](err)
+	}
+
+	// 任务可能在开始前被取消，开始时间用原子变量避免竞态
+	var start atomic.Int64
+	f := SupplyAsyncCtxWithExecutor(ctx, executor, func() T {
+		start.Store(time.Now().UnixNano())
+		return supplier()
+	})
+	return afterRecord(f, true, func(err error) {
+		ns := start.Load()
+		if ns == 0 || callerCanceled(err) {
+			cb.release(generation)
+			return
+		}
+		cb.record(generation, err, time.Since(time.Unix(0, ns)))
+	})
+}
+
+// ExecuteWithBreaker 通过熔断器调用任意返回 Future 的函数，耗时从调用 fn 开始计算
+// 被调用方取消的调用只归还许可，不计入统计；取消返回的阶段不会取消 fn 返回的 Future
+func ExecuteWithBreaker[T any](cb *CircuitBreaker, fn func() *CompletableFuture[T]) *CompletableFuture[T] {
+	if fn == nil {
+		return FailedFuture[T](ErrNilFunction)
+	}
+	generation, err := cb.acquire()
+	if err != nil {
+		return FailedFuture[T](err)
+	}
+
+	start := time.Now()
+	f, panicErr := safecall(fn)
+	if panicErr == nil && f == nil {
+		panicErr = ErrNilFunction
+	}
+	if panicErr != nil {
+		cb.record(generation, panicErr, time.Since(start))
+		return FailedFuture[T](panicErr)
+	}
+	return afterRecord(f, false, func(err error) {
+		if callerCanceled(err) {
+			cb.release(generation)
+			return
+		}
+		cb.record(generation, err, time.Since(start))
+	})
+}
+
+// afterRecord 返回在 record 执行之后才完成的新阶段，调用方 Join 返回时熔断器已计入本次调用
+// owned 为 true 表示 f 由熔断器创建，取消返回的阶段会同时取消 f，使排队中的任务不再执行；
+// 否则 f 属于调用方，取消只以 ErrCanceled 调用 record 释放许可，f 之后的结果不再计入
+func afterRecord[T any](f *CompletableFuture[T], owned bool, record func(err error)) *CompletableFuture[T] {
+	dest := newStage[T](f)
+	var once sync.Once
+	f.whenCompleteInternal(func(val T, err error) {
+		once.Do(func() { record(err) })
+		dest.completeWith(val, err)
+	})
+	dest.whenCompleteInternal(func(T, error) {
+		if !dest.IsCancelled() {
+			return
+		}
+		if owned {
+			f.Cancel(true)
+			return
+		}
+		once.Do(func() { record(ErrCanceled) })
+	})
+	return dest
+}
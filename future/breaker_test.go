@@ -0,0 +1,189 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:   4,
+		MinimumCalls: 4,
+		OpenDuration: time.Hour,
+	})
+
+	for i := 0; i < 4; i++ {
+		SupplyAsyncWithBreaker(cb, func() int {
+			if i%2 == 0 {
+				panic("down")
+			}
+			return i
+		}).Join()
+	}
+
+	assertEqual(t, cb.State(), StateOpen)
+
+	_, err := SupplyAsyncWithBreaker(cb, func() int { return 1 }).Join()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenRecovery(t *testing.T) {
+	var mu sync.Mutex
+	var transitions []string
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:       2,
+		MinimumCalls:     2,
+		OpenDuration:     20 * time.Millisecond,
+		HalfOpenMaxCalls: 2,
+	})
+	cb.OnStateChange(func(from, to CircuitState) {
+		mu.Lock()
+		transitions = append(transitions, from.String()+"->"+to.String())
+		mu.Unlock()
+	})
+
+	for i := 0; i < 2; i++ {
+		ExecuteWithBreaker(cb, func() *CompletableFuture[int] {
+			return FailedFuture[int](errFlaky)
+		}).Join()
+	}
+	assertEqual(t, cb.State(), StateOpen)
+
+	time.Sleep(30 * time.Millisecond)
+	assertEqual(t, cb.State(), StateHalfOpen)
+
+	// 半开状态下只放行 2 个试探调用
+	gate := New[int]()
+	p1 := ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return gate })
+	p2 := ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return gate })
+	_, err := ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return gate }).Join()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen for excess half-open call, got %v", err)
+	}
+
+	gate.Complete(1)
+	p1.Join()
+	p2.Join()
+	assertEqual(t, cb.State(), StateClosed)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("Expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		assertEqual(t, transitions[i], want[i])
+	}
+}
+
+func TestCircuitBreaker_SlowCalls(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:            2,
+		MinimumCalls:          2,
+		SlowCallDuration:      10 * time.Millisecond,
+		SlowCallRateThreshold: 1,
+		OpenDuration:          time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		SupplyAsyncWithBreaker(cb, func() int {
+			time.Sleep(15 * time.Millisecond)
+			return 1
+		}).Join()
+	}
+	assertEqual(t, cb.State(), StateOpen)
+}
+
+func TestCircuitBreaker_IgnoredErrors(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:   2,
+		MinimumCalls: 2,
+		IsFailure:    func(err error) bool { return !errors.Is(err, errFlaky) },
+	})
+	for i := 0; i < 4; i++ {
+		ExecuteWithBreaker(cb, func() *CompletableFuture[int] {
+			return FailedFuture[int](errFlaky)
+		}).Join()
+	}
+	assertEqual(t, cb.State(), StateClosed)
+}
+
+func TestCircuitBreaker_IgnoresCallerCancellation(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:       2,
+		MinimumCalls:     2,
+		OpenDuration:     20 * time.Millisecond,
+		HalfOpenMaxCalls: 1,
+	})
+
+	// 排队中被取消的调用从未到达下游，不能打开熔断器
+	gate := make(chan struct{})
+	defer close(gate)
+	exec := &gatedExecutor{gate: gate}
+	ran := false
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		f := SupplyAsyncCtxWithBreaker(ctx, cb, exec, func() int { ran = true; return 1 })
+		cancel()
+		f.Cancel(true)
+		f.Join()
+	}
+	assertEqual(t, cb.State(), StateClosed)
+
+	for i := 0; i < 2; i++ {
+		ExecuteWithBreaker(cb, func() *CompletableFuture[int] {
+			return FailedFuture[int](errFlaky)
+		}).Join()
+	}
+	assertEqual(t, cb.State(), StateOpen)
+	time.Sleep(30 * time.Millisecond)
+	assertEqual(t, cb.State(), StateHalfOpen)
+
+	// 取消的试探调用归还许可，不占用唯一的半开名额
+	probe := New[int]()
+	ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return probe })
+	probe.Cancel(true)
+	assertEqual(t, cb.State(), StateHalfOpen)
+	val, err := ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return CompletedFuture(1) }).Join()
+	if err != nil || val != 1 {
+		t.Fatalf("Expected the released permit to be reusable, got %v, %v", val, err)
+	}
+	assertEqual(t, cb.State(), StateClosed)
+	if ran {
+		t.Error("Cancelled supplier must not run")
+	}
+}
+
+func TestExecuteWithBreaker_CancelDoesNotCancelCallerFuture(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		WindowSize:       1,
+		MinimumCalls:     1,
+		OpenDuration:     20 * time.Millisecond,
+		HalfOpenMaxCalls: 1,
+	})
+	ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return FailedFuture[int](errFlaky) }).Join()
+	time.Sleep(30 * time.Millisecond)
+	assertEqual(t, cb.State(), StateHalfOpen)
+
+	// 调用方自己的 Future 可能被其他地方共享，取消熔断器返回的阶段不能取消它
+	shared := New[int]()
+	ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return shared }).Cancel(true)
+	if shared.IsDone() {
+		t.Fatal("Caller's future must not be cancelled")
+	}
+
+	// 半开名额已归还，shared 之后的结果不再计入
+	val, err := ExecuteWithBreaker(cb, func() *CompletableFuture[int] { return CompletedFuture(1) }).Join()
+	if err != nil || val != 1 {
+		t.Fatalf("Expected the released permit to be reusable, got %v, %v", val, err)
+	}
+	assertEqual(t, cb.State(), StateClosed)
+	shared.CompleteExceptionally(errFlaky)
+	assertEqual(t, cb.State(), StateClosed)
+}
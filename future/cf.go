@@ -47,6 +47,9 @@ type CompletableFuture[T any] struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	// executor 默认执行器，未指定执行器的异步阶段使用它，为 nil 时使用 pool.Global()
+	executor pool.Executor
+
 	_ [8]uint64
 }
 
@@ -69,6 +72,30 @@ func NewWithContext[T any](parent context.Context) *CompletableFuture[T] {
 	return f
 }
 
+// newStage 创建派生阶段的 Future
+// 继承上游 context 中的值（如 pool.WithPriority 设置的优先级），使整条链的异步阶段带有相同标签，
+// 但不继承上游的取消与截止时间
+func newStage[V any, T any](src *CompletableFuture[T]) *CompletableFuture[V] {
+	return &CompletableFuture[V]{
+		state:    statePending,
+		ctx:      stageContext(src.ctx),
+		executor: src.executor,
+	}
+}
+
+func stageContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	if ctx.Done() == nil {
+		// 不可取消的 context（Background 或仅携带值）可以直接共享，避免额外分配
+		if _, ok := ctx.Deadline(); !ok {
+			return ctx
+		}
+	}
+	return context.WithoutCancel(ctx)
+}
+
 // ============ State Inspection ============
 
 func (f *CompletableFuture[T]) IsDone() bool {
@@ -139,6 +166,27 @@ func (f *CompletableFuture[T]) Get(ctx context.Context) (T, error) {
 	}
 }
 
+// JoinManaged 在 exec 的任务中等待完成，等待期间 exec 补偿一个并发名额，避免嵌套 Join 耗尽执行器（见 pool.ManagedBlock）
+func (f *CompletableFuture[T]) JoinManaged(exec pool.Executor) (T, error) {
+	if atomic.LoadInt32(&f.state) == stateDone {
+		return f.value, f.err
+	}
+	done := f.getDoneChanLazy()
+	pool.ManagedBlock(exec, func() { <-done })
+	return f.value, f.err
+}
+
+// GetManaged 在 exec 的任务中等待完成或 ctx 结束，等待方式同 JoinManaged
+func (f *CompletableFuture[T]) GetManaged(ctx context.Context, exec pool.Executor) (T, error) {
+	if atomic.LoadInt32(&f.state) == stateDone {
+		return f.value, f.err
+	}
+	var val T
+	var err error
+	pool.ManagedBlock(exec, func() { val, err = f.Get(ctx) })
+	return val, err
+}
+
 // getDoneChanLazy 获取等待通道
 // 【核心修复】：解决 close of closed channel Panic，并实现 Zero Alloc
 func (f *CompletableFuture[T]) getDoneChanLazy() chan struct{} {
@@ -182,6 +230,14 @@ func (f *CompletableFuture[T]) CompleteExceptionally(err error) bool {
 	return true
 }
 
+// completeWith 根据 err 是否为空，以正常值或异常完成 Future
+func (f *CompletableFuture[T]) completeWith(val T, err error) bool {
+	if err != nil {
+		return f.CompleteExceptionally(err)
+	}
+	return f.Complete(val)
+}
+
 func (f *CompletableFuture[T]) finishCompletion() {
 	atomic.StoreInt32(&f.state, stateDone)
 
@@ -211,11 +267,7 @@ func (f *CompletableFuture[T]) CompleteAsync(supplier func() T) *CompletableFutu
 }
 
 func (f *CompletableFuture[T]) CompleteAsyncWithExecutor(executor pool.Executor, supplier func() T) *CompletableFuture[T] {
-	exec := executor
-	if exec == nil {
-		exec = pool.GlobalExecutor
-	}
-	exec.Submit(func() {
+	f.executorOr(executor).Submit(func() {
 		res, err := safecall(func() T { return supplier() })
 		if err != nil {
 			f.CompleteExceptionally(err)
@@ -226,6 +278,31 @@ func (f *CompletableFuture[T]) CompleteAsyncWithExecutor(executor pool.Executor,
 	return f
 }
 
+// DefaultExecutor 返回默认执行器（对应 Java 的 defaultExecutor()）
+// 由 *WithExecutor 创建的 Future 使用创建时的执行器，派生阶段继承上游的默认执行器，都未指定时为当前的全局执行器 pool.Global()
+func (f *CompletableFuture[T]) DefaultExecutor() pool.Executor {
+	if f.executor != nil {
+		return f.executor
+	}
+	return pool.Global()
+}
+
+// WithDefaultExecutor 返回与 f 同时完成的新阶段，它及其派生阶段的默认执行器为 executor
+func (f *CompletableFuture[T]) WithDefaultExecutor(executor pool.Executor) *CompletableFuture[T] {
+	dest := newStage[T](f)
+	dest.executor = executor
+	f.whenCompleteInternal(func(val T, err error) { dest.completeWith(val, err) })
+	return dest
+}
+
+// executorOr 优先使用显式指定的执行器，否则使用默认执行器
+func (f *CompletableFuture[T]) executorOr(executor pool.Executor) pool.Executor {
+	if executor != nil {
+		return executor
+	}
+	return f.DefaultExecutor()
+}
+
 func (f *CompletableFuture[T]) whenCompleteInternal(cb callback[T]) {
 	if atomic.LoadInt32(&f.state) == stateDone {
 		cb(f.value, f.err)
@@ -0,0 +1,36 @@
+//go:build linux
+
+package future
+
+import (
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/xigexb/go-future/pool"
+)
+
+func TestSupplyAsyncPinned_ChainStaysOnThread(t *testing.T) {
+	exec := pool.NewLockedThreadExecutor(4)
+	defer exec.Shutdown()
+
+	// 每个阶段先让出处理器，未锁定线程时恢复后可能换到其他线程
+	tid := func() int {
+		runtime.Gosched()
+		return syscall.Gettid()
+	}
+	var tids []int
+	f := SupplyAsyncPinned(exec, func() int { tids = append(tids, tid()); return 1 })
+	for i := 0; i < 5; i++ {
+		f = ThenApplyAsync(f, func(v int) int { tids = append(tids, tid()); return v + 1 })
+	}
+	val, err := f.Join()
+	if err != nil || val != 6 {
+		t.Fatalf("Expected 6, got %v, %v", val, err)
+	}
+	for _, id := range tids[1:] {
+		if id != tids[0] {
+			t.Fatalf("Chain left its pinned thread: %v", tids)
+		}
+	}
+}
@@ -110,7 +110,7 @@ func TestThenApplyAsync_WithCustomExecutor(t *testing.T) {
 
 func TestGlobalExecutor_Replacement(t *testing.T) {
 	// 保存旧的，测试完恢复
-	original := pool.GlobalExecutor
+	original := pool.Global()
 	defer pool.SetGlobalExecutor(original)
 
 	mock := &mockExecutor{}
@@ -128,9 +128,11 @@ func TestGlobalExecutor_Replacement(t *testing.T) {
 
 func TestContext_Cancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
+	// 手动驱动的执行器保证取消发生在任务开始之前
+	exec := pool.NewManualExecutor()
 
 	// 创建一个会阻塞的任务
-	f := SupplyAsyncCtx(ctx, func() int {
+	f := SupplyAsyncCtxWithExecutor(ctx, exec, func() int {
 		time.Sleep(1 * time.Second)
 		return 1
 	})
@@ -142,6 +144,28 @@ func TestContext_Cancellation(t *testing.T) {
 	if !errors.Is(err, context.Canceled) {
 		t.Errorf("Expected context.Canceled, got %v", err)
 	}
+	exec.RunUntilIdle()
+}
+
+func TestContext_CancelAfterStartKeepsResult(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	f := SupplyAsyncCtx(ctx, func() int {
+		close(started)
+		<-release
+		return 1
+	})
+
+	// 任务开始后不再监听 context，取消不影响它的结果
+	<-started
+	cancel()
+	close(release)
+
+	v, err := f.Join()
+	if err != nil || v != 1 {
+		t.Errorf("Expected 1, got %v, %v", v, err)
+	}
 }
 
 func TestOrTimeout_Legacy(t *testing.T) {
@@ -212,3 +236,480 @@ func TestRaceCondition(t *testing.T) {
 
 	wg.Wait()
 }
+
+// ============ 感知 context 的执行器 ============
+
+func TestSupplyAsync_CancelledWhileQueuedOnRateLimiter(t *testing.T) {
+	exec := pool.NewRateLimitedExecutor(pool.NewBlockingExecutor(2), 10, 1)
+	exec.Submit(func() {})
+
+	var ran int32
+	f := SupplyAsyncWithExecutor(exec, func() int {
+		atomic.StoreInt32(&ran, 1)
+		return 1
+	})
+	f.Cancel(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f2 := SupplyAsyncCtxWithExecutor(ctx, exec, func() int {
+		atomic.StoreInt32(&ran, 1)
+		return 2
+	})
+	cancel()
+
+	// 父 context 结束时，即使任务被执行器丢弃，Future 也应立即完成
+	if _, err := f2.Join(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Error("Cancelled suppliers should not run")
+	}
+	if exec.Dropped() != 2 {
+		t.Errorf("Expected 2 dropped tasks, got %d", exec.Dropped())
+	}
+}
+
+// ============ 拒绝策略 ============
+
+func TestTrySupplyAsync_Rejected(t *testing.T) {
+	exec := pool.NewWorkerPool(1, 0)
+	release := make(chan struct{})
+	defer close(release)
+	blocker := RunAsyncWithExecutor(exec, func() { <-release })
+	time.Sleep(10 * time.Millisecond)
+
+	f := TrySupplyAsync(exec, func() int { return 1 })
+	if _, err := f.Join(); !errors.Is(err, pool.ErrRejected) {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+	if blocker.IsDone() {
+		t.Fatal("Blocker should still be running")
+	}
+}
+
+func TestSupplyAsync_DiscardOldestFailsFuture(t *testing.T) {
+	exec := pool.NewWorkerPoolWithConfig(pool.WorkerPoolConfig{
+		MinWorkers: 1,
+		QueueSize:  1,
+		Rejection:  pool.DiscardOldestPolicy,
+	})
+	release := make(chan struct{})
+	RunAsyncWithExecutor(exec, func() { <-release })
+	time.Sleep(10 * time.Millisecond)
+
+	oldest := SupplyAsyncWithExecutor(exec, func() int { return 1 })
+	newest := SupplyAsyncWithExecutor(exec, func() int { return 2 })
+	close(release)
+
+	if _, err := oldest.Join(); !errors.Is(err, pool.ErrRejected) {
+		t.Fatalf("Expected oldest future to fail with ErrRejected, got %v", err)
+	}
+	val, err := newest.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 2)
+}
+
+func TestSupplyAsync_AfterShutdown(t *testing.T) {
+	exec := pool.NewWorkerPool(1, 1)
+	exec.Shutdown()
+
+	_, err := SupplyAsyncWithExecutor(exec, func() int { return 1 }).Join()
+	if !errors.Is(err, pool.ErrShutdown) {
+		t.Fatalf("Expected ErrShutdown, got %v", err)
+	}
+}
+
+// ============ 优先级 ============
+
+func TestSupplyAsyncWithPriority_InheritedByAsyncStages(t *testing.T) {
+	exec := pool.NewPriorityExecutor(1, 0)
+	defer exec.Shutdown()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	RunAsyncWithExecutor(exec, func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		order = append(order, name)
+		mu.Unlock()
+	}
+
+	// 第二个阶段在低优先级任务之后才入队，继承优先级后仍应先于它们执行
+	high := ThenApplyAsyncWithExecutor(
+		SupplyAsyncWithPriority(exec, 10, func() int { record("high-1"); return 1 }),
+		exec,
+		func(v int) int { record("high-2"); return v + 1 },
+	)
+	low1 := RunAsyncWithPriority(exec, 1, func() { record("low-1") })
+	low2 := RunAsyncWithPriority(exec, 1, func() { record("low-2") })
+	close(release)
+
+	_, _ = high.Join()
+	_, _ = low1.Join()
+	_, _ = low2.Join()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"high-1", "high-2", "low-1", "low-2"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Derived async stage should inherit priority, expected %v, got %v", want, order)
+		}
+	}
+}
+
+// ============ 按 key 串行 ============
+
+func TestSupplyAsyncKeyed_ChainStaysOnKey(t *testing.T) {
+	exec := pool.NewKeyedExecutor(pool.NewBlockingExecutor(4))
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	gate := make(chan struct{})
+	first := SupplyAsyncKeyed(exec, "acct", func() int {
+		<-gate
+		record("supply")
+		return 1
+	})
+	second := ThenApplyAsyncWithExecutor(first, exec.ForKey("acct"), func(v int) int {
+		record("apply")
+		return v + 1
+	})
+	third := RunAsyncKeyed(exec, "acct", func() { record("run") })
+	close(gate)
+
+	val, err := second.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 2)
+	_, _ = third.Join()
+
+	mu.Lock()
+	defer mu.Unlock()
+	// apply 在 supply 完成后才提交，排在 run 之后
+	want := []string{"supply", "run", "apply"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, order)
+		}
+	}
+}
+
+// ============ 默认执行器 ============
+
+func TestDefaultExecutor_InheritedByDerivedStages(t *testing.T) {
+	io := &mockExecutor{}
+	f := SupplyAsyncWithExecutor(io, func() int { return 1 })
+	if f.DefaultExecutor() != pool.Executor(io) {
+		t.Fatal("Future created with an executor should use it as default")
+	}
+
+	g := ThenApplyAsync(f, func(v int) int { return v + 1 }).
+		HandleAsync(func(v int, err error) int { return v * 10 })
+	h := ThenCombineAsync(g, CompletedFuture(5), func(a, b int) int { return a + b }).
+		ExceptionallyAsync(func(err error) (int, error) { return -1, nil })
+
+	val, err := h.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 25)
+	// supply + apply + handle + combine；Exceptionally 在成功时直接透传，不提交任务
+	assertEqual(t, atomic.LoadInt32(&io.submitCount), int32(4))
+	if h.DefaultExecutor() != pool.Executor(io) {
+		t.Error("Derived stages should inherit the default executor")
+	}
+	if New[int]().DefaultExecutor() != pool.Global() {
+		t.Error("Futures without an executor should default to GlobalExecutor")
+	}
+}
+
+func TestWithExecutorVariants(t *testing.T) {
+	exec := &mockExecutor{}
+	failed := FailedFuture[int](errors.New("boom"))
+
+	v1, _ := failed.ExceptionallyAsyncWithExecutor(exec, func(error) (int, error) { return 1, nil }).Join()
+	v2, _ := failed.ExceptionallyComposeAsyncWithExecutor(exec, func(error) *CompletableFuture[int] {
+		return CompletedFuture(2)
+	}).Join()
+	v3, _ := failed.HandleAsyncWithExecutor(exec, func(int, error) int { return 3 }).Join()
+	v4, _ := ApplyToEitherAsyncWithExecutor(CompletedFuture(4), New[int](), exec, func(v int) int { return v }).Join()
+	_, _ = RunAfterBothAsyncWithExecutor(CompletedFuture(1), CompletedFuture(2), exec, func() {}).Join()
+
+	assertEqual(t, v1+v2+v3+v4, 10)
+	assertEqual(t, atomic.LoadInt32(&exec.submitCount), int32(5))
+}
+
+func TestWithDefaultExecutor(t *testing.T) {
+	exec := &mockExecutor{}
+	f := CompletedFuture(1).WithDefaultExecutor(exec)
+	val, err := ThenApplyAsync(f, func(v int) int { return v + 1 }).Join()
+	assertNil(t, err)
+	assertEqual(t, val, 2)
+	assertEqual(t, atomic.LoadInt32(&exec.submitCount), int32(1))
+}
+
+// ============ 确定性执行器 ============
+
+func TestManualExecutor_DeterministicChain(t *testing.T) {
+	exec := pool.NewManualExecutor()
+	f1 := SupplyAsyncWithExecutor(exec, func() int { return 1 })
+	f2 := SupplyAsyncWithExecutor(exec, func() int { return 2 })
+	// 两个上游谁先完成完全由手动执行的顺序决定
+	either := ApplyToEitherAsync(f1, f2, func(v int) int { return v * 10 })
+
+	if exec.Pending() != 2 {
+		t.Fatalf("Expected 2 queued suppliers, got %d", exec.Pending())
+	}
+	exec.RunNext()
+	if !f1.IsDone() || either.IsDone() {
+		t.Fatal("Only f1 should be complete after one step")
+	}
+	// either 的任务在 f1 完成时提交，排在 f2 之后
+	exec.RunUntilIdle()
+
+	val, err := either.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 10)
+}
+
+func TestSyncExecutor_RunsChainInline(t *testing.T) {
+	var order []string
+	f := SupplyAsyncWithExecutor(pool.SyncExecutor{}, func() int {
+		order = append(order, "supply")
+		return 1
+	})
+	ThenApplyAsync(f, func(v int) int { order = append(order, "apply"); return v }).
+		ThenAcceptAsync(func(int) { order = append(order, "accept") })
+	order = append(order, "after")
+
+	want := []string{"supply", "apply", "accept", "after"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestGlobalExecutor_SwapWhileSubmitting(t *testing.T) {
+	// 队列足够大，避免 worker 在提交下一阶段时因队列已满而互相阻塞
+	original := pool.SetGlobalExecutor(pool.NewWorkerPool(2, 1024))
+	defer pool.SetGlobalExecutor(original)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			_ = pool.ReplaceGlobalExecutor(ctx, pool.NewWorkerPool(2, 1024))
+			cancel()
+		}
+	}()
+
+	var futures []*CompletableFuture[int]
+	for i := 0; i < 200; i++ {
+		futures = append(futures, ThenApplyAsync(SupplyAsync(func() int { return 1 }), func(v int) int { return v }))
+	}
+	<-done
+	for _, f := range futures {
+		// 与替换竞争的提交可能被已关闭的旧执行器拒绝，但不会挂起
+		if _, err := f.Join(); err != nil && !errors.Is(err, pool.ErrShutdown) {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func TestJoin_NestedOnBoundedExecutors(t *testing.T) {
+	executors := map[string]pool.Executor{
+		"blocking":    pool.NewBlockingExecutor(2),
+		"worker_pool": pool.NewWorkerPool(2, 2),
+		"priority":    pool.NewPriorityExecutor(2, 0),
+		"fairshare":   pool.NewFairShareExecutor(2, pool.TenantConfig{}),
+		"deadline":    pool.NewDeadlineExecutor(2),
+	}
+	for name, exec := range executors {
+		t.Run(name, func(t *testing.T) {
+			// 每一层都在 worker 上 Join 下一层，深度超过并发上限
+			var nested func(depth int) int
+			nested = func(depth int) int {
+				if depth == 0 {
+					return 0
+				}
+				f := SupplyAsyncWithExecutor(exec, func() int { return nested(depth-1) + 1 })
+				if depth%2 == 0 {
+					v, _ := f.JoinManaged(exec)
+					return v
+				}
+				v, _ := f.GetManaged(context.Background(), exec)
+				return v
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			v, err := SupplyAsyncWithExecutor(exec, func() int { return nested(6) }).Get(ctx)
+			if err != nil || v != 6 {
+				t.Fatalf("Expected 6, got %v, %v", v, err)
+			}
+		})
+	}
+}
+
+func TestSupplyAsyncTenant_ChainStaysOnTenant(t *testing.T) {
+	exec := pool.NewFairShareExecutor(2, pool.TenantConfig{})
+	defer exec.Shutdown()
+
+	f := SupplyAsyncTenant(exec, "acme", func() int { return 1 })
+	g := ThenApplyAsync(f, func(v int) int { return v + 1 })
+	h := RunAsyncTenant(exec, "acme", func() {})
+
+	val, err := g.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 2)
+	_, _ = h.Join()
+
+	s, ok := exec.TenantStats("acme")
+	if !ok || s.Submitted != 3 {
+		t.Errorf("Expected 3 tasks on tenant, got %+v", s)
+	}
+}
+
+func TestSupplyAsyncWeighted(t *testing.T) {
+	exec := pool.NewBlockingExecutorWithPolicy(4, pool.AbortPolicy)
+
+	gate := make(chan struct{})
+	heavy := SupplyAsyncWeighted(exec, 3, func() int { <-gate; return 1 })
+
+	_, err := SupplyAsyncWeighted(exec, 2, func() int { return 2 }).Join()
+	if !errors.Is(err, pool.ErrRejected) {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+	_, err = RunAsyncWeighted(exec, 1, func() {}).Join()
+	assertNil(t, err)
+
+	close(gate)
+	val, err := heavy.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 1)
+}
+
+// weightRecorder 记录每次提交的权重，SubmitCtx 记为 1
+type weightRecorder struct {
+	mu      sync.Mutex
+	weights []int
+}
+
+func (r *weightRecorder) Submit(task pool.Runnable) {
+	_ = r.SubmitWeighted(context.Background(), 1, task)
+}
+
+func (r *weightRecorder) SubmitCtx(ctx context.Context, task pool.Runnable) error {
+	return r.SubmitWeighted(ctx, 1, task)
+}
+
+func (r *weightRecorder) SubmitWeighted(_ context.Context, weight int, task pool.Runnable) error {
+	r.mu.Lock()
+	r.weights = append(r.weights, weight)
+	r.mu.Unlock()
+	go task()
+	return nil
+}
+
+func TestSupplyAsyncWeighted_DerivedStagesUseWeightOne(t *testing.T) {
+	exec := &weightRecorder{}
+	f := SupplyAsyncWeighted(exec, 3, func() int { return 1 })
+	val, err := ThenApplyAsync(f, func(v int) int { return v + 1 }).Join()
+	assertNil(t, err)
+	assertEqual(t, val, 2)
+	exec.mu.Lock()
+	weights := exec.weights
+	exec.mu.Unlock()
+	if len(weights) != 2 || weights[0] != 3 || weights[1] != 1 {
+		t.Errorf("Expected only the supplier to be weighted, got %v", weights)
+	}
+
+	_, err = SupplyAsyncWeighted(pool.SyncExecutor{}, 2, func() int { return 1 }).Join()
+	if !errors.Is(err, pool.ErrWeightUnsupported) {
+		t.Fatalf("Expected ErrWeightUnsupported, got %v", err)
+	}
+}
+
+func TestDeadlineExecutor_FailsExpiredFuture(t *testing.T) {
+	exec := pool.NewDeadlineExecutor(1)
+	defer exec.Shutdown()
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	blocker := RunAsyncWithExecutor(exec, func() { close(started); <-gate })
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	ran := false
+	f := SupplyAsyncCtxWithExecutor(ctx, exec, func() int { ran = true; return 1 })
+	_, err := f.Join()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	close(gate)
+	_, _ = blocker.Join()
+	if ran {
+		t.Error("Expired task must not run")
+	}
+	deadline := time.Now().Add(time.Second)
+	for exec.Shed() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if exec.Shed() != 1 {
+		t.Errorf("Expected 1 shed task, got %d", exec.Shed())
+	}
+}
+
+func TestMemoryGuard_FailsFutureWithOverloadError(t *testing.T) {
+	// 阈值 1 字节，任何堆大小都会超过
+	exec := pool.NewMemoryGuard(pool.NewBlockingExecutor(2), pool.MemoryGuardConfig{MaxHeapBytes: 1})
+
+	ran := false
+	f := SupplyAsyncWithExecutor(exec, func() int { ran = true; return 1 })
+	_, err := f.Join()
+	var oe *pool.OverloadError
+	if !errors.As(err, &oe) {
+		t.Fatalf("Expected *OverloadError, got %v", err)
+	}
+	if !errors.Is(err, pool.ErrOverloaded) {
+		t.Error("Expected errors.Is(err, ErrOverloaded)")
+	}
+	if ran {
+		t.Error("Rejected task must not run")
+	}
+}
+
+func TestForkJoinPool_ShutdownNowFailsFuture(t *testing.T) {
+	p := pool.NewForkJoinPool(1)
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	blocker := RunAsyncWithExecutor(p, func() { close(started); <-gate })
+	<-started
+
+	f := SupplyAsyncWithExecutor(p, func() int { return 1 })
+	p.ShutdownNow()
+	close(gate)
+	_, _ = blocker.Join()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := f.Get(ctx); !errors.Is(err, pool.ErrShutdown) {
+		t.Fatalf("Expected ErrShutdown, got %v", err)
+	}
+}
@@ -21,7 +21,7 @@ func ThenApplyAsyncWithExecutor[T any, V any](src *CompletableFuture[T], executo
 }
 
 func uniApply[T any, V any](src *CompletableFuture[T], fn func(T) V, async bool, executor pool.Executor) *CompletableFuture[V] {
-	dest := New[V]()
+	dest := newStage[V](src)
 
 	execTask := func(val T, err error) {
 		if err != nil {
@@ -37,11 +37,7 @@ func uniApply[T any, V any](src *CompletableFuture[T], fn func(T) V, async bool,
 			}
 		}
 		if async {
-			exec := executor
-			if exec == nil {
-				exec = pool.GlobalExecutor
-			}
-			exec.Submit(task)
+			submit(dest, src.executorOr(executor), task, false)
 		} else {
 			task()
 		}
@@ -99,7 +95,7 @@ func ThenComposeAsyncWithExecutor[T any, V any](src *CompletableFuture[T], execu
 }
 
 func uniCompose[T any, V any](src *CompletableFuture[T], fn func(T) *CompletableFuture[V], async bool, executor pool.Executor) *CompletableFuture[V] {
-	dest := New[V]()
+	dest := newStage[V](src)
 
 	execTask := func(val T, err error) {
 		if err != nil {
@@ -138,11 +134,7 @@ func uniCompose[T any, V any](src *CompletableFuture[T], fn func(T) *Completable
 			}
 		}
 		if async {
-			exec := executor
-			if exec == nil {
-				exec = pool.GlobalExecutor
-			}
-			exec.Submit(task)
+			submit(dest, src.executorOr(executor), task, false)
 		} else {
 			task()
 		}
@@ -171,7 +163,7 @@ func (f *CompletableFuture[T]) WhenCompleteAsyncWithExecutor(executor pool.Execu
 }
 
 func uniWhenComplete[T any](src *CompletableFuture[T], action func(T, error), async bool, executor pool.Executor) *CompletableFuture[T] {
-	dest := New[T]()
+	dest := newStage[T](src)
 
 	execTask := func(val T, err error) {
 		task := func() {
@@ -186,11 +178,7 @@ func uniWhenComplete[T any](src *CompletableFuture[T], action func(T, error), as
 			}
 		}
 		if async {
-			exec := executor
-			if exec == nil {
-				exec = pool.GlobalExecutor
-			}
-			exec.Submit(task)
+			submit(dest, src.executorOr(executor), task, false)
 		} else {
 			task()
 		}
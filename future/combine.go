@@ -62,13 +62,18 @@ func AnyOf[T any](futures ...*CompletableFuture[T]) *CompletableFuture[T] {
 }
 
 // ============ Binary: AND (ThenCombine) ============
+// 异步变体未指定执行器时使用 f1 的默认执行器
 
 func ThenCombine[T any, U any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], fn func(T, U) V) *CompletableFuture[V] {
-	return biApply(f1, f2, fn, false)
+	return biApply(f1, f2, fn, false, nil)
 }
 
 func ThenCombineAsync[T any, U any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], fn func(T, U) V) *CompletableFuture[V] {
-	return biApply(f1, f2, fn, true)
+	return biApply(f1, f2, fn, true, nil)
+}
+
+func ThenCombineAsyncWithExecutor[T any, U any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], executor pool.Executor, fn func(T, U) V) *CompletableFuture[V] {
+	return biApply(f1, f2, fn, true, executor)
 }
 
 // ThenAcceptBoth
@@ -80,6 +85,10 @@ func ThenAcceptBothAsync[T any, U any](f1 *CompletableFuture[T], f2 *Completable
 	return ThenCombineAsync(f1, f2, func(t T, u U) struct{} { fn(t, u); return struct{}{} })
 }
 
+func ThenAcceptBothAsyncWithExecutor[T any, U any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], executor pool.Executor, fn func(T, U)) *CompletableFuture[struct{}] {
+	return ThenCombineAsyncWithExecutor(f1, f2, executor, func(t T, u U) struct{} { fn(t, u); return struct{}{} })
+}
+
 // RunAfterBoth
 func RunAfterBoth[T any, U any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], action func()) *CompletableFuture[struct{}] {
 	return ThenCombine(f1, f2, func(_ T, _ U) struct{} { action(); return struct{}{} })
@@ -89,11 +98,18 @@ func RunAfterBothAsync[T any, U any](f1 *CompletableFuture[T], f2 *CompletableFu
 	return ThenCombineAsync(f1, f2, func(_ T, _ U) struct{} { action(); return struct{}{} })
 }
 
-func biApply[T any, U any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], fn func(T, U) V, async bool) *CompletableFuture[V] {
-	dest := New[V]()
-	// 简单的非阻塞实现：在一个新协程等待两者
-	// 注：这里为了简化逻辑使用 Join，更底层的实现应该使用计数器回调
-	pool.GlobalExecutor.Submit(func() {
+func RunAfterBothAsyncWithExecutor[T any, U any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], executor pool.Executor, action func()) *CompletableFuture[struct{}] {
+	return ThenCombineAsyncWithExecutor(f1, f2, executor, func(_ T, _ U) struct{} { action(); return struct{}{} })
+}
+
+func biApply[T any, U any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[U], fn func(T, U) V, async bool, executor pool.Executor) *CompletableFuture[V] {
+	dest := newStage[V](f1)
+	// 两者都完成后触发；f1 失败时立即失败，f2 的错误在 f1 成功后才生效
+	var pending int32 = 2
+	fire := func() {
+		if atomic.AddInt32(&pending, -1) != 0 {
+			return
+		}
 		v1, err1 := f1.Join()
 		if err1 != nil {
 			dest.CompleteExceptionally(err1)
@@ -113,22 +129,33 @@ func biApply[T any, U any, V any](f1 *CompletableFuture[T], f2 *CompletableFutur
 			}
 		}
 		if async {
-			pool.GlobalExecutor.Submit(task)
+			submit(dest, f1.executorOr(executor), task, false)
 		} else {
 			task()
 		}
+	}
+	f1.whenCompleteInternal(func(_ T, err error) {
+		if err != nil {
+			dest.CompleteExceptionally(err)
+		}
+		fire()
 	})
+	f2.whenCompleteInternal(func(U, error) { fire() })
 	return dest
 }
 
 // ============ Binary: OR (ApplyToEither) ============
 
 func ApplyToEither[T any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], fn func(T) V) *CompletableFuture[V] {
-	return orApply(f1, f2, fn, false)
+	return orApply(f1, f2, fn, false, nil)
 }
 
 func ApplyToEitherAsync[T any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], fn func(T) V) *CompletableFuture[V] {
-	return orApply(f1, f2, fn, true)
+	return orApply(f1, f2, fn, true, nil)
+}
+
+func ApplyToEitherAsyncWithExecutor[T any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], executor pool.Executor, fn func(T) V) *CompletableFuture[V] {
+	return orApply(f1, f2, fn, true, executor)
 }
 
 func AcceptEither[T any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], fn func(T)) *CompletableFuture[struct{}] {
@@ -139,6 +166,10 @@ func AcceptEitherAsync[T any](f1 *CompletableFuture[T], f2 *CompletableFuture[T]
 	return ApplyToEitherAsync(f1, f2, func(t T) struct{} { fn(t); return struct{}{} })
 }
 
+func AcceptEitherAsyncWithExecutor[T any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], executor pool.Executor, fn func(T)) *CompletableFuture[struct{}] {
+	return ApplyToEitherAsyncWithExecutor(f1, f2, executor, func(t T) struct{} { fn(t); return struct{}{} })
+}
+
 func RunAfterEither[T any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], action func()) *CompletableFuture[struct{}] {
 	return ApplyToEither(f1, f2, func(_ T) struct{} { action(); return struct{}{} })
 }
@@ -147,8 +178,12 @@ func RunAfterEitherAsync[T any](f1 *CompletableFuture[T], f2 *CompletableFuture[
 	return ApplyToEitherAsync(f1, f2, func(_ T) struct{} { action(); return struct{}{} })
 }
 
-func orApply[T any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], fn func(T) V, async bool) *CompletableFuture[V] {
-	dest := New[V]()
+func RunAfterEitherAsyncWithExecutor[T any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], executor pool.Executor, action func()) *CompletableFuture[struct{}] {
+	return ApplyToEitherAsyncWithExecutor(f1, f2, executor, func(_ T) struct{} { action(); return struct{}{} })
+}
+
+func orApply[T any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], fn func(T) V, async bool, executor pool.Executor) *CompletableFuture[V] {
+	dest := newStage[V](f1)
 	var done int32 = 0
 	cb := func(val T, err error) {
 		if atomic.CompareAndSwapInt32(&done, 0, 1) {
@@ -165,7 +200,7 @@ func orApply[T any, V any](f1 *CompletableFuture[T], f2 *CompletableFuture[T], f
 				}
 			}
 			if async {
-				pool.GlobalExecutor.Submit(task)
+				submit(dest, f1.executorOr(executor), task, false)
 			} else {
 				task()
 			}
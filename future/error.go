@@ -7,15 +7,19 @@ import (
 
 // Exceptionally
 func (f *CompletableFuture[T]) Exceptionally(fn func(error) (T, error)) *CompletableFuture[T] {
-	return uniExceptionally(f, fn, false)
+	return uniExceptionally(f, fn, false, nil)
 }
 
 func (f *CompletableFuture[T]) ExceptionallyAsync(fn func(error) (T, error)) *CompletableFuture[T] {
-	return uniExceptionally(f, fn, true)
+	return uniExceptionally(f, fn, true, nil)
 }
 
-func uniExceptionally[T any](f *CompletableFuture[T], fn func(error) (T, error), async bool) *CompletableFuture[T] {
-	dest := New[T]()
+func (f *CompletableFuture[T]) ExceptionallyAsyncWithExecutor(executor pool.Executor, fn func(error) (T, error)) *CompletableFuture[T] {
+	return uniExceptionally(f, fn, true, executor)
+}
+
+func uniExceptionally[T any](f *CompletableFuture[T], fn func(error) (T, error), async bool, executor pool.Executor) *CompletableFuture[T] {
+	dest := newStage[T](f)
 	f.whenCompleteInternal(func(val T, err error) {
 		if err == nil {
 			dest.Complete(val)
@@ -35,7 +39,7 @@ func uniExceptionally[T any](f *CompletableFuture[T], fn func(error) (T, error),
 			}
 		}
 		if async {
-			pool.GlobalExecutor.Submit(task)
+			submit(dest, f.executorOr(executor), task, false)
 		} else {
 			task()
 		}
@@ -45,15 +49,19 @@ func uniExceptionally[T any](f *CompletableFuture[T], fn func(error) (T, error),
 
 // ExceptionallyCompose 对应 Java 12: exceptionallyCompose
 func (f *CompletableFuture[T]) ExceptionallyCompose(fn func(error) *CompletableFuture[T]) *CompletableFuture[T] {
-	return uniExceptionallyCompose(f, fn, false)
+	return uniExceptionallyCompose(f, fn, false, nil)
 }
 
 func (f *CompletableFuture[T]) ExceptionallyComposeAsync(fn func(error) *CompletableFuture[T]) *CompletableFuture[T] {
-	return uniExceptionallyCompose(f, fn, true)
+	return uniExceptionallyCompose(f, fn, true, nil)
+}
+
+func (f *CompletableFuture[T]) ExceptionallyComposeAsyncWithExecutor(executor pool.Executor, fn func(error) *CompletableFuture[T]) *CompletableFuture[T] {
+	return uniExceptionallyCompose(f, fn, true, executor)
 }
 
-func uniExceptionallyCompose[T any](f *CompletableFuture[T], fn func(error) *CompletableFuture[T], async bool) *CompletableFuture[T] {
-	dest := New[T]()
+func uniExceptionallyCompose[T any](f *CompletableFuture[T], fn func(error) *CompletableFuture[T], async bool, executor pool.Executor) *CompletableFuture[T] {
+	dest := newStage[T](f)
 	f.whenCompleteInternal(func(val T, err error) {
 		if err == nil {
 			dest.Complete(val)
@@ -79,7 +87,7 @@ func uniExceptionallyCompose[T any](f *CompletableFuture[T], fn func(error) *Com
 			})
 		}
 		if async {
-			pool.GlobalExecutor.Submit(task)
+			submit(dest, f.executorOr(executor), task, false)
 		} else {
 			task()
 		}
@@ -89,15 +97,19 @@ func uniExceptionallyCompose[T any](f *CompletableFuture[T], fn func(error) *Com
 
 // Handle
 func (f *CompletableFuture[T]) Handle(fn func(T, error) T) *CompletableFuture[T] {
-	return uniHandle(f, fn, false)
+	return uniHandle(f, fn, false, nil)
 }
 
 func (f *CompletableFuture[T]) HandleAsync(fn func(T, error) T) *CompletableFuture[T] {
-	return uniHandle(f, fn, true)
+	return uniHandle(f, fn, true, nil)
+}
+
+func (f *CompletableFuture[T]) HandleAsyncWithExecutor(executor pool.Executor, fn func(T, error) T) *CompletableFuture[T] {
+	return uniHandle(f, fn, true, executor)
 }
 
-func uniHandle[T any](f *CompletableFuture[T], fn func(T, error) T, async bool) *CompletableFuture[T] {
-	dest := New[T]()
+func uniHandle[T any](f *CompletableFuture[T], fn func(T, error) T, async bool, executor pool.Executor) *CompletableFuture[T] {
+	dest := newStage[T](f)
 	f.whenCompleteInternal(func(val T, err error) {
 		task := func() {
 			defer func() {
@@ -109,7 +121,7 @@ func uniHandle[T any](f *CompletableFuture[T], fn func(T, error) T, async bool)
 			dest.Complete(res)
 		}
 		if async {
-			pool.GlobalExecutor.Submit(task)
+			submit(dest, f.executorOr(executor), task, false)
 		} else {
 			task()
 		}
@@ -0,0 +1,52 @@
+package future
+
+import (
+	"github.com/xigexb/go-future/pool"
+)
+
+// ============ Fork / Join (RecursiveTask 风格) ============
+
+// SupplyForkJoin 向 ForkJoinPool 提交根任务，task 可以通过 w 继续 Fork 子任务
+func SupplyForkJoin[T any](p *pool.ForkJoinPool, task func(w *pool.ForkJoinWorker) T) *CompletableFuture[T] {
+	f := New[T]()
+	f.executor = p
+	if task == nil {
+		f.CompleteExceptionally(ErrNilFunction)
+		return f
+	}
+	if err := p.SubmitWorkerTask(forkTask(f, task)); err != nil {
+		f.CompleteExceptionally(err)
+	}
+	return f
+}
+
+// Fork 在当前 worker 上派生子任务，子任务进入 w 的本地队列，空闲 worker 可以窃取
+// 必须在 w 正在执行的任务内部调用，等待结果请使用 JoinOn(w)
+func Fork[T any](w *pool.ForkJoinWorker, task func(w *pool.ForkJoinWorker) T) *CompletableFuture[T] {
+	f := New[T]()
+	if task == nil {
+		f.CompleteExceptionally(ErrNilFunction)
+		return f
+	}
+	w.Fork(forkTask(f, task))
+	return f
+}
+
+// JoinOn 在 ForkJoin worker 内部等待结果
+// 等待期间 worker 会继续执行本地或窃取来的任务，因此递归 Fork/Join 不会耗尽 worker 导致死锁
+func (f *CompletableFuture[T]) JoinOn(w *pool.ForkJoinWorker) (T, error) {
+	if w != nil && !f.IsDone() {
+		w.HelpUntil(f.getDoneChanLazy())
+	}
+	return f.Join()
+}
+
+func forkTask[T any](f *CompletableFuture[T], task func(w *pool.ForkJoinWorker) T) pool.WorkerTask {
+	return func(w *pool.ForkJoinWorker) {
+		if f.IsDone() {
+			return
+		}
+		val, err := safecall(func() T { return task(w) })
+		f.completeWith(val, err)
+	}
+}
@@ -0,0 +1,68 @@
+package future
+
+import (
+	"testing"
+
+	"github.com/xigexb/go-future/pool"
+)
+
+// sumRange 经典的分治求和，递归深度远大于 worker 数
+func sumRange(w *pool.ForkJoinWorker, lo, hi int) int {
+	if hi-lo <= 16 {
+		s := 0
+		for i := lo; i < hi; i++ {
+			s += i
+		}
+		return s
+	}
+	mid := (lo + hi) / 2
+	left := Fork(w, func(w *pool.ForkJoinWorker) int { return sumRange(w, lo, mid) })
+	right := sumRange(w, mid, hi)
+	l, err := left.JoinOn(w)
+	if err != nil {
+		panic(err)
+	}
+	return l + right
+}
+
+func TestForkJoin_RecursiveSum(t *testing.T) {
+	// 只有 2 个 worker，如果 Join 阻塞 worker 会立即死锁
+	p := pool.NewForkJoinPool(2)
+	defer p.Shutdown()
+
+	n := 100000
+	val, err := SupplyForkJoin(p, func(w *pool.ForkJoinWorker) int {
+		return sumRange(w, 0, n)
+	}).Join()
+	assertNil(t, err)
+	assertEqual(t, val, n*(n-1)/2)
+}
+
+func TestForkJoin_PanicInSubtask(t *testing.T) {
+	p := pool.NewForkJoinPool(2)
+	defer p.Shutdown()
+
+	_, err := SupplyForkJoin(p, func(w *pool.ForkJoinWorker) int {
+		sub := Fork(w, func(*pool.ForkJoinWorker) int { panic("subtask") })
+		v, err := sub.JoinOn(w)
+		if err != nil {
+			panic(err)
+		}
+		return v
+	}).Join()
+	if err == nil {
+		t.Fatal("Expected panic to propagate as error")
+	}
+}
+
+func TestForkJoinPool_AsExecutor(t *testing.T) {
+	p := pool.NewForkJoinPool(2)
+	val, err := SupplyAsyncWithExecutor(p, func() int { return 3 }).Join()
+	assertNil(t, err)
+	assertEqual(t, val, 3)
+
+	p.Shutdown()
+	if _, err := SupplyAsyncWithExecutor(p, func() int { return 3 }).Join(); err != pool.ErrShutdown {
+		t.Fatalf("Expected ErrShutdown, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package future
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xigexb/go-future/pool"
+)
+
+// ============ Backoff 退避策略 ============
+
+// Backoff 计算第 attempt 次失败后（从 1 开始）到下一次尝试之间的等待时长
+// prev 为上一次的等待时长，首次为 0
+type Backoff func(attempt int, prev time.Duration) time.Duration
+
+// ConstantBackoff 固定间隔
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(_ int, _ time.Duration) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff 指数退避：initial * multiplier^(attempt-1)，不超过 maxDelay
+// multiplier <= 1 时按 2 处理，maxDelay <= 0 表示不设上限
+func ExponentialBackoff(initial, maxDelay time.Duration, multiplier float64) Backoff {
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+	return func(attempt int, _ time.Duration) time.Duration {
+		d := float64(initial)
+		for i := 1; i < attempt; i++ {
+			d *= multiplier
+			if maxDelay > 0 && d >= float64(maxDelay) {
+				return maxDelay
+			}
+		}
+		if maxDelay > 0 && d > float64(maxDelay) {
+			return maxDelay
+		}
+		return time.Duration(d)
+	}
+}
+
+// DecorrelatedJitterBackoff 去相关抖动退避 (AWS Architecture Blog)
+// sleep = min(maxDelay, random_between(base, prev * 3))
+func DecorrelatedJitterBackoff(base, maxDelay time.Duration) Backoff {
+	return func(_ int, prev time.Duration) time.Duration {
+		if prev < base {
+			prev = base
+		}
+		upper := prev * 3
+		d := base
+		if upper > base {
+			d = base + rand.N(upper-base)
+		}
+		if maxDelay > 0 && d > maxDelay {
+			d = maxDelay
+		}
+		return d
+	}
+}
+
+// ============ RetryPolicy ============
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	// MaxAttempts 最大尝试次数（包含首次），<= 0 表示不限次数
+	MaxAttempts int
+	// Backoff 两次尝试之间的等待策略，nil 表示立即重试
+	Backoff Backoff
+	// MaxElapsed 从首次尝试开始的总体时间预算，下一次尝试会超出预算时放弃，0 表示不限
+	MaxElapsed time.Duration
+	// RetryIf 判断错误是否可重试，nil 表示所有错误都重试
+	RetryIf func(error) bool
+}
+
+// RetryError 重试最终失败时返回的错误，记录尝试次数及每次失败的原因
+// 如果是 context 结束导致的放弃，ctx.Err() 会作为最后一个错误
+type RetryError struct {
+	Attempts int
+	Errors   []error
+}
+
+func (e *RetryError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("retry failed after %d attempt(s): [%s]", e.Attempts, strings.Join(msgs, "; "))
+}
+
+// Unwrap 支持 errors.Is / errors.As 匹配任意一次失败的原因
+func (e *RetryError) Unwrap() []error {
+	return e.Errors
+}
+
+// Last 返回最后一次失败的原因
+func (e *RetryError) Last() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors[len(e.Errors)-1]
+}
+
+// ============ Retry ============
+
+// Retry 按照 policy 异步执行 supplier，失败时重试
+// 等待期间不占用执行器（基于定时器重新提交），ctx 结束或 Future 被取消时立即停止
+func Retry[T any](ctx context.Context, policy RetryPolicy, supplier func(context.Context) (T, error)) *CompletableFuture[T] {
+	return RetryWithExecutor(ctx, nil, policy, supplier)
+}
+
+func RetryWithExecutor[T any](ctx context.Context, executor pool.Executor, policy RetryPolicy, supplier func(context.Context) (T, error)) *CompletableFuture[T] {
+	f := NewWithContext[T](ctx)
+	if supplier == nil {
+		f.CompleteExceptionally(ErrNilFunction)
+		return f
+	}
+
+	f.executor = executor
+	exec := f.DefaultExecutor()
+
+	r := &retrier[T]{
+		dest:     f,
+		exec:     exec,
+		policy:   policy,
+		supplier: supplier,
+		start:    time.Now(),
+	}
+	if f.ctx.Done() != nil {
+		stop := context.AfterFunc(f.ctx, func() {
+			r.fail(f.ctx.Err())
+		})
+		f.whenCompleteInternal(func(_ T, _ error) { stop() })
+	}
+	r.attempt()
+	return f
+}
+
+type retrier[T any] struct {
+	dest     *CompletableFuture[T]
+	exec     pool.Executor
+	policy   RetryPolicy
+	supplier func(context.Context) (T, error)
+	start    time.Time
+
+	mu       sync.Mutex
+	attempts int
+	errs     []error
+	delay    time.Duration
+}
+
+func (r *retrier[T]) attempt() {
+	r.exec.Submit(func() {
+		if r.dest.IsDone() {
+			return
+		}
+		var val T
+		var err error
+		_, panicErr := safecall(func() int {
+			val, err = r.supplier(r.dest.ctx)
+			return 0
+		})
+		if panicErr != nil {
+			err = panicErr
+		}
+		if err == nil {
+			r.dest.Complete(val)
+			return
+		}
+		r.onError(err)
+	})
+}
+
+func (r *retrier[T]) onError(err error) {
+	r.mu.Lock()
+	r.attempts++
+	r.errs = append(r.errs, err)
+
+	if !r.shouldRetry(err) {
+		r.mu.Unlock()
+		r.fail(nil)
+		return
+	}
+
+	var delay time.Duration
+	if r.policy.Backoff != nil {
+		delay = r.policy.Backoff(r.attempts, r.delay)
+	}
+	if r.policy.MaxElapsed > 0 && time.Since(r.start)+delay > r.policy.MaxElapsed {
+		r.mu.Unlock()
+		r.fail(nil)
+		return
+	}
+	r.delay = delay
+	r.mu.Unlock()
+
+	if delay <= 0 {
+		r.attempt()
+		return
+	}
+	time.AfterFunc(delay, r.attempt)
+}
+
+// shouldRetry 调用方需持有锁
+func (r *retrier[T]) shouldRetry(err error) bool {
+	if r.policy.MaxAttempts > 0 && r.attempts >= r.policy.MaxAttempts {
+		return false
+	}
+	if r.policy.RetryIf != nil && !r.policy.RetryIf(err) {
+		return false
+	}
+	return true
+}
+
+// fail 以 RetryError 结束 Future，cause 非空时追加到错误列表末尾
+func (r *retrier[T]) fail(cause error) {
+	r.mu.Lock()
+	errs := make([]error, len(r.errs), len(r.errs)+1)
+	copy(errs, r.errs)
+	if cause != nil {
+		errs = append(errs, cause)
+	}
+	retryErr := &RetryError{Attempts: r.attempts, Errors: errs}
+	r.mu.Unlock()
+
+	r.dest.CompleteExceptionally(retryErr)
+}
@@ -0,0 +1,129 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errFlaky = errors.New("flaky")
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	var calls int32
+	f := Retry(context.Background(), RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     ConstantBackoff(time.Millisecond),
+	}, func(ctx context.Context) (int, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return 0, errFlaky
+		}
+		return 42, nil
+	})
+
+	val, err := f.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 42)
+	assertEqual(t, atomic.LoadInt32(&calls), int32(3))
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	f := Retry(context.Background(), RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ExponentialBackoff(time.Millisecond, 5*time.Millisecond, 2),
+	}, func(ctx context.Context) (int, error) {
+		return 0, errFlaky
+	})
+
+	_, err := f.Join()
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected *RetryError, got %v", err)
+	}
+	assertEqual(t, retryErr.Attempts, 3)
+	assertEqual(t, len(retryErr.Errors), 3)
+	if !errors.Is(err, errFlaky) {
+		t.Error("RetryError should match the underlying error")
+	}
+}
+
+func TestRetry_NonRetryable(t *testing.T) {
+	fatal := errors.New("fatal")
+	var calls int32
+	f := Retry(context.Background(), RetryPolicy{
+		RetryIf: func(err error) bool { return !errors.Is(err, fatal) },
+	}, func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, fatal
+	})
+
+	_, err := f.Join()
+	if !errors.Is(err, fatal) {
+		t.Fatalf("Expected fatal error, got %v", err)
+	}
+	assertEqual(t, atomic.LoadInt32(&calls), int32(1))
+}
+
+func TestRetry_PanicIsRetried(t *testing.T) {
+	var calls int32
+	f := Retry(context.Background(), RetryPolicy{MaxAttempts: 2}, func(ctx context.Context) (string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			panic("boom")
+		}
+		return "ok", nil
+	})
+	val, err := f.Join()
+	assertNil(t, err)
+	assertEqual(t, val, "ok")
+}
+
+func TestRetry_ContextCancelDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := Retry(ctx, RetryPolicy{
+		Backoff: ConstantBackoff(time.Hour),
+	}, func(ctx context.Context) (int, error) {
+		return 0, errFlaky
+	})
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	_, err := f.Join()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		assertEqual(t, retryErr.Attempts, 1)
+	}
+}
+
+func TestRetry_MaxElapsed(t *testing.T) {
+	var calls int32
+	f := Retry(context.Background(), RetryPolicy{
+		Backoff:    ConstantBackoff(30 * time.Millisecond),
+		MaxElapsed: 50 * time.Millisecond,
+	}, func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, errFlaky
+	})
+
+	_, err := f.Join()
+	if !errors.Is(err, errFlaky) {
+		t.Fatalf("Expected errFlaky, got %v", err)
+	}
+	assertEqual(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func TestDecorrelatedJitterBackoff_Bounds(t *testing.T) {
+	b := DecorrelatedJitterBackoff(10*time.Millisecond, 100*time.Millisecond)
+	var prev time.Duration
+	for i := 1; i <= 50; i++ {
+		d := b(i, prev)
+		if d < 10*time.Millisecond || d > 100*time.Millisecond {
+			t.Fatalf("Delay out of bounds: %v", d)
+		}
+		prev = d
+	}
+}
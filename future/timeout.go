@@ -1,7 +1,13 @@
 package future
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
+
+	"github.com/xigexb/go-future/pool"
 )
 
 // OrTimeout 如果在指定时间内未完成，则抛出 ErrTimeout 异常
@@ -40,3 +46,212 @@ func (f *CompletableFuture[T]) CompleteOnTimeout(value T, d time.Duration) *Comp
 	}()
 	return f
 }
+
+// WithTimeout 返回一个新的 Future，不修改源 Future
+// 源 Future 在 d 内完成则透传其结果，否则新 Future 以 ErrTimeout 失败
+// cancelSource 为 true 时，超时会同时 Cancel 源 Future，从而取消其 context，让尚未开始的任务直接跳过
+func (f *CompletableFuture[T]) WithTimeout(d time.Duration, cancelSource bool) *CompletableFuture[T] {
+	return f.WithDeadline(time.Now().Add(d), cancelSource)
+}
+
+// WithDeadline 与 WithTimeout 相同，但使用绝对截止时间
+func (f *CompletableFuture[T]) WithDeadline(deadline time.Time, cancelSource bool) *CompletableFuture[T] {
+	dest := newStage[T](f)
+	if f.IsDone() {
+		dest.completeWith(f.value, f.err)
+		return dest
+	}
+
+	// settled 决定由超时还是源 Future 完成 dest：超时一方先取消源再唤醒 dest 的等待者，
+	// 等待者返回时源 Future 已经取消，且 Cancel 触发的回调不会把 dest 的错误改成 ErrCanceled
+	var settled atomic.Bool
+	timer := time.AfterFunc(time.Until(deadline), func() {
+		if !settled.CompareAndSwap(false, true) {
+			return
+		}
+		if cancelSource {
+			f.Cancel(true)
+		}
+		dest.CompleteExceptionally(ErrTimeout)
+	})
+	f.whenCompleteInternal(func(val T, err error) {
+		timer.Stop()
+		if settled.CompareAndSwap(false, true) {
+			dest.completeWith(val, err)
+		}
+	})
+	return dest
+}
+
+// WithContextDeadline 返回一个新的 Future，在 ctx 结束时失败
+// ctx 因截止时间到期而结束时返回 ErrTimeout，否则返回 ctx.Err()
+// cancelSource 的语义与 WithTimeout 相同
+func (f *CompletableFuture[T]) WithContextDeadline(ctx context.Context, cancelSource bool) *CompletableFuture[T] {
+	dest := newStage[T](f)
+	if f.IsDone() {
+		dest.completeWith(f.value, f.err)
+		return dest
+	}
+
+	// settled 的作用同 WithDeadline
+	var settled atomic.Bool
+	stop := context.AfterFunc(ctx, func() {
+		if !settled.CompareAndSwap(false, true) {
+			return
+		}
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = ErrTimeout
+		}
+		if cancelSource {
+			f.Cancel(true)
+		}
+		dest.CompleteExceptionally(err)
+	})
+	f.whenCompleteInternal(func(val T, err error) {
+		stop()
+		if settled.CompareAndSwap(false, true) {
+			dest.completeWith(val, err)
+		}
+	})
+	return dest
+}
+
+// ============ 单阶段超时 ============
+
+// StageTimeoutError 单个阶段超时的错误，errors.Is(err, ErrTimeout) 为 true
+type StageTimeoutError struct {
+	Stage   string
+	Timeout time.Duration
+}
+
+func (e *StageTimeoutError) Error() string {
+	return fmt.Sprintf("stage %q timed out after %v", e.Stage, e.Timeout)
+}
+
+func (e *StageTimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// ThenApplyWithTimeout 与 ThenApply 相同，但只限制本阶段的耗时：
+// 从上游成功完成开始计时，d 内未完成则以 *StageTimeoutError 失败。
+// 上游的失败照常透传，不计入本阶段。
+// 超时后本阶段被放弃：尚未开始的异步任务不再执行，已经在运行的 fn 无法中断，会继续运行但结果被丢弃
+func ThenApplyWithTimeout[T any, V any](src *CompletableFuture[T], stage string, d time.Duration, fn func(T) V) *CompletableFuture[V] {
+	return withStageTimeout(src, stage, d, func() *CompletableFuture[V] {
+		return uniApply(src, fn, false, nil)
+	}, nil)
+}
+
+func ThenApplyAsyncWithTimeout[T any, V any](src *CompletableFuture[T], stage string, d time.Duration, fn func(T) V) *CompletableFuture[V] {
+	return ThenApplyAsyncWithTimeoutWithExecutor(src, nil, stage, d, fn)
+}
+
+// ThenApplyAsyncWithTimeoutWithExecutor 在 executor 上执行 fn，计时包含在 executor 中排队的时间
+func ThenApplyAsyncWithTimeoutWithExecutor[T any, V any](src *CompletableFuture[T], executor pool.Executor, stage string, d time.Duration, fn func(T) V) *CompletableFuture[V] {
+	return withStageTimeout(src, stage, d, func() *CompletableFuture[V] {
+		return uniApply(src, fn, true, executor)
+	}, nil)
+}
+
+// ThenComposeWithTimeout 计时覆盖 fn 本身以及 fn 返回的 Future，超时会 Cancel fn 返回的 Future
+func ThenComposeWithTimeout[T any, V any](src *CompletableFuture[T], stage string, d time.Duration, fn func(T) *CompletableFuture[V]) *CompletableFuture[V] {
+	return composeWithStageTimeout(src, stage, d, fn, false, nil)
+}
+
+func ThenComposeAsyncWithTimeout[T any, V any](src *CompletableFuture[T], stage string, d time.Duration, fn func(T) *CompletableFuture[V]) *CompletableFuture[V] {
+	return ThenComposeAsyncWithTimeoutWithExecutor(src, nil, stage, d, fn)
+}
+
+func ThenComposeAsyncWithTimeoutWithExecutor[T any, V any](src *CompletableFuture[T], executor pool.Executor, stage string, d time.Duration, fn func(T) *CompletableFuture[V]) *CompletableFuture[V] {
+	return composeWithStageTimeout(src, stage, d, fn, true, executor)
+}
+
+// composeWithStageTimeout 记录 fn 返回的 Future，本阶段超时时取消它
+// fn 可能在超时之后才返回，双方各自先写再读对方的标记，至少有一方能看到对方并完成取消
+func composeWithStageTimeout[T any, V any](src *CompletableFuture[T], stage string, d time.Duration, fn func(T) *CompletableFuture[V], async bool, executor pool.Executor) *CompletableFuture[V] {
+	var relay atomic.Pointer[CompletableFuture[V]]
+	var timedOut atomic.Bool
+	return withStageTimeout(src, stage, d, func() *CompletableFuture[V] {
+		return uniCompose(src, func(v T) *CompletableFuture[V] {
+			r := fn(v)
+			if r != nil {
+				relay.Store(r)
+				if timedOut.Load() {
+					r.Cancel(true)
+				}
+			}
+			return r
+		}, async, executor)
+	}, func() {
+		timedOut.Store(true)
+		if r := relay.Load(); r != nil {
+			r.Cancel(true)
+		}
+	})
+}
+
+func (f *CompletableFuture[T]) ThenAcceptWithTimeout(stage string, d time.Duration, fn func(T)) *CompletableFuture[struct{}] {
+	return ThenApplyWithTimeout(f, stage, d, func(v T) struct{} { fn(v); return struct{}{} })
+}
+
+func (f *CompletableFuture[T]) ThenAcceptAsyncWithTimeout(stage string, d time.Duration, fn func(T)) *CompletableFuture[struct{}] {
+	return f.ThenAcceptAsyncWithTimeoutWithExecutor(nil, stage, d, fn)
+}
+
+func (f *CompletableFuture[T]) ThenAcceptAsyncWithTimeoutWithExecutor(executor pool.Executor, stage string, d time.Duration, fn func(T)) *CompletableFuture[struct{}] {
+	return ThenApplyAsyncWithTimeoutWithExecutor(f, executor, stage, d, func(v T) struct{} { fn(v); return struct{}{} })
+}
+
+func (f *CompletableFuture[T]) ThenRunWithTimeout(stage string, d time.Duration, action func()) *CompletableFuture[struct{}] {
+	return ThenApplyWithTimeout(f, stage, d, func(_ T) struct{} { action(); return struct{}{} })
+}
+
+func (f *CompletableFuture[T]) ThenRunAsyncWithTimeout(stage string, d time.Duration, action func()) *CompletableFuture[struct{}] {
+	return f.ThenRunAsyncWithTimeoutWithExecutor(nil, stage, d, action)
+}
+
+func (f *CompletableFuture[T]) ThenRunAsyncWithTimeoutWithExecutor(executor pool.Executor, stage string, d time.Duration, action func()) *CompletableFuture[struct{}] {
+	return ThenApplyAsyncWithTimeoutWithExecutor(f, executor, stage, d, func(_ T) struct{} { action(); return struct{}{} })
+}
+
+// withStageTimeout 在上游成功完成时启动计时器，再构建本阶段
+// 计时回调先于阶段回调注册，保证同步阶段在执行 fn 之前就已开始计时。
+// 超时时先取消内部阶段并调用 abandon，再唤醒 dest 的等待者，等待者返回时排队中的任务已被放弃
+func withStageTimeout[T any, V any](src *CompletableFuture[T], stage string, d time.Duration, build func() *CompletableFuture[V], abandon func()) *CompletableFuture[V] {
+	dest := newStage[V](src)
+	// settled 的作用同 WithDeadline：内部阶段因取消而完成时不会把 dest 的错误改成 ErrCanceled
+	var settled, timedOut atomic.Bool
+	var inner atomic.Pointer[CompletableFuture[V]]
+	src.whenCompleteInternal(func(_ T, err error) {
+		if err != nil {
+			return
+		}
+		timer := time.AfterFunc(d, func() {
+			if !settled.CompareAndSwap(false, true) {
+				return
+			}
+			timedOut.Store(true)
+			if f := inner.Load(); f != nil {
+				f.Cancel(true)
+			}
+			if abandon != nil {
+				abandon()
+			}
+			dest.CompleteExceptionally(&StageTimeoutError{Stage: stage, Timeout: d})
+		})
+		dest.whenCompleteInternal(func(_ V, _ error) { timer.Stop() })
+	})
+	f := build()
+	inner.Store(f)
+	// 构建期间已经超时
+	if timedOut.Load() {
+		f.Cancel(true)
+	}
+	f.whenCompleteInternal(func(val V, err error) {
+		if settled.CompareAndSwap(false, true) {
+			dest.completeWith(val, err)
+		}
+	})
+	return dest
+}
@@ -0,0 +1,238 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xigexb/go-future/pool"
+)
+
+func TestWithTimeout_DoesNotMutateSource(t *testing.T) {
+	src := New[int]()
+	f := src.WithTimeout(20*time.Millisecond, false)
+
+	_, err := f.Join()
+	if err != ErrTimeout {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if src.IsDone() {
+		t.Fatal("Source future should not be completed by WithTimeout")
+	}
+
+	src.Complete(1)
+	val, err := src.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 1)
+}
+
+func TestWithTimeout_CompletesInTime(t *testing.T) {
+	f := SupplyAsync(func() int { return 7 }).WithTimeout(time.Second, true)
+	val, err := f.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 7)
+}
+
+func TestWithTimeout_CancelSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := NewWithContext[int](ctx)
+	f := src.WithTimeout(20*time.Millisecond, true)
+
+	_, err := f.Join()
+	if err != ErrTimeout {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if !src.IsCancelled() {
+		t.Fatal("Source future should be cancelled")
+	}
+	if src.ctx.Err() == nil {
+		t.Fatal("Source context should be cancelled")
+	}
+}
+
+func TestWithTimeout_CancelSourceSkipsQueuedTask(t *testing.T) {
+	gate := make(chan struct{})
+	var ran int32
+
+	// 任务在 gate 关闭前一直排队
+	src := SupplyAsyncWithExecutor(&gatedExecutor{gate: gate}, func() int {
+		atomic.StoreInt32(&ran, 1)
+		return 1
+	})
+
+	f := src.WithTimeout(10*time.Millisecond, true)
+	if _, err := f.Join(); err != ErrTimeout {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	close(gate)
+	time.Sleep(20 * time.Millisecond)
+
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Fatal("Supplier should be skipped after the source was cancelled")
+	}
+}
+
+func TestWithDeadline_Past(t *testing.T) {
+	f := New[int]().WithDeadline(time.Now().Add(-time.Second), false)
+	if _, err := f.Join(); err != ErrTimeout {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestWithContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	src := New[int]()
+	if _, err := src.WithContextDeadline(ctx, true).Join(); err != ErrTimeout {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if !src.IsCancelled() {
+		t.Fatal("Source future should be cancelled")
+	}
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	f := New[int]().WithContextDeadline(ctx2, false)
+	cancel2()
+	if _, err := f.Join(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// gatedExecutor 在 gate 关闭前不执行任务
+type gatedExecutor struct {
+	gate chan struct{}
+}
+
+func (g *gatedExecutor) Submit(task pool.Runnable) {
+	go func() {
+		<-g.gate
+		task()
+	}()
+}
+
+func TestThenApplyWithTimeout(t *testing.T) {
+	src := SupplyAsync(func() int {
+		time.Sleep(30 * time.Millisecond) // 上游耗时不计入本阶段
+		return 1
+	})
+	ok := ThenApplyWithTimeout(src, "fast", 20*time.Millisecond, func(v int) int { return v + 1 })
+	val, err := ok.Join()
+	assertNil(t, err)
+	assertEqual(t, val, 2)
+
+	slow := ThenApplyAsyncWithTimeout(src, "slow", 10*time.Millisecond, func(v int) int {
+		time.Sleep(50 * time.Millisecond)
+		return v
+	})
+	_, err = slow.Join()
+	var stageErr *StageTimeoutError
+	if !errors.As(err, &stageErr) {
+		t.Fatalf("Expected *StageTimeoutError, got %v", err)
+	}
+	assertEqual(t, stageErr.Stage, "slow")
+	if !errors.Is(err, ErrTimeout) {
+		t.Error("StageTimeoutError should match ErrTimeout")
+	}
+}
+
+func TestThenApplyWithTimeout_SyncStage(t *testing.T) {
+	f := ThenApplyWithTimeout(CompletedFuture(1), "inline", 10*time.Millisecond, func(v int) int {
+		time.Sleep(40 * time.Millisecond)
+		return v
+	})
+	if _, err := f.Join(); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected stage timeout, got %v", err)
+	}
+}
+
+func TestThenComposeWithTimeout(t *testing.T) {
+	src := CompletedFuture(1)
+	f := ThenComposeWithTimeout(src, "remote", 10*time.Millisecond, func(v int) *CompletableFuture[string] {
+		return New[string]() // 永远不会完成
+	})
+	_, err := f.Join()
+	var stageErr *StageTimeoutError
+	if !errors.As(err, &stageErr) || stageErr.Stage != "remote" {
+		t.Fatalf("Expected stage timeout for remote, got %v", err)
+	}
+
+	upstreamErr := errors.New("upstream")
+	f2 := FailedFuture[int](upstreamErr).ThenRunWithTimeout("after", time.Millisecond, func() {})
+	if _, err := f2.Join(); err != upstreamErr {
+		t.Fatalf("Expected upstream error to pass through, got %v", err)
+	}
+}
+
+func TestThenComposeWithTimeout_CancelsInnerFuture(t *testing.T) {
+	inner := New[string]()
+	f := ThenComposeWithTimeout(CompletedFuture(1), "remote", 10*time.Millisecond, func(int) *CompletableFuture[string] {
+		return inner
+	})
+	if _, err := f.Join(); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected stage timeout, got %v", err)
+	}
+	if !inner.IsCancelled() {
+		t.Error("Inner composed future should be cancelled on timeout")
+	}
+
+	// fn 在超时之后才返回 Future，同样会被取消
+	src := New[int]()
+	gate := make(chan struct{})
+	late := New[string]()
+	f2 := ThenComposeAsyncWithTimeout(src, "late", 10*time.Millisecond, func(int) *CompletableFuture[string] {
+		<-gate
+		return late
+	})
+	src.Complete(1)
+	if _, err := f2.Join(); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected stage timeout, got %v", err)
+	}
+	close(gate)
+	deadline := time.Now().Add(time.Second)
+	for !late.IsCancelled() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !late.IsCancelled() {
+		t.Error("Future returned after the timeout should be cancelled")
+	}
+}
+
+func TestThenApplyAsyncWithTimeout_SkipsQueuedTask(t *testing.T) {
+	exec := pool.NewManualExecutor()
+	var ran int32
+	f := ThenApplyAsyncWithTimeoutWithExecutor(CompletedFuture(1), exec, "queued", 10*time.Millisecond, func(v int) int {
+		atomic.StoreInt32(&ran, 1)
+		return v
+	})
+	if _, err := f.Join(); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected stage timeout, got %v", err)
+	}
+	exec.RunUntilIdle()
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Error("Task still queued at timeout should not run")
+	}
+}
+
+func TestAsyncWithTimeoutWithExecutor(t *testing.T) {
+	exec := &mockExecutor{}
+	src := CompletedFuture(1)
+
+	v, err := ThenApplyAsyncWithTimeoutWithExecutor(src, exec, "apply", time.Second, func(v int) int { return v + 1 }).Join()
+	assertNil(t, err)
+	assertEqual(t, v, 2)
+	_, err = ThenComposeAsyncWithTimeoutWithExecutor(src, exec, "compose", time.Second, func(v int) *CompletableFuture[int] {
+		return CompletedFuture(v)
+	}).Join()
+	assertNil(t, err)
+	_, err = src.ThenAcceptAsyncWithTimeoutWithExecutor(exec, "accept", time.Second, func(int) {}).Join()
+	assertNil(t, err)
+	_, err = src.ThenRunAsyncWithTimeoutWithExecutor(exec, "run", time.Second, func() {}).Join()
+	assertNil(t, err)
+
+	assertEqual(t, atomic.LoadInt32(&exec.submitCount), int32(4))
+}
@@ -0,0 +1,270 @@
+package pool
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LimitAlgorithm 自适应并发限制算法
+type LimitAlgorithm int
+
+const (
+	// AIMDLimit 加性增、乘性减：任务延迟未超过阈值且并发接近上限时每个样本加 1/limit，即每轮（约 limit 个样本）加 1，
+	// 超过阈值或 panic 时乘以 BackoffRatio
+	AIMDLimit LimitAlgorithm = iota
+	// GradientLimit 梯度算法（参考 Netflix Gradient2）：按长期与短期平均延迟之比收缩上限，
+	// 延迟平稳时以 sqrt(limit) 的余量增长
+	GradientLimit
+)
+
+// AdaptiveConfig 自适应执行器配置
+type AdaptiveConfig struct {
+	// Algorithm 限制算法，默认 AIMDLimit
+	Algorithm LimitAlgorithm
+	// InitialLimit 初始并发上限，默认 20
+	InitialLimit int
+	// MinLimit / MaxLimit 并发上限的调整范围，默认 1 与 200
+	MinLimit int
+	MaxLimit int
+	// LatencyThreshold AIMD 判定过载的任务耗时，默认为长期平均耗时的 2 倍
+	LatencyThreshold time.Duration
+	// BackoffRatio AIMD 过载时上限乘以该值，默认 0.9
+	BackoffRatio float64
+	// Tolerance Gradient 允许短期延迟高出长期延迟的倍数，超过后开始收缩，默认 1.5
+	Tolerance float64
+	// Rejection 达到上限后如何处理新任务：BlockPolicy（默认）阻塞提交者排队等待，其余策略同 blockingExecutor
+	Rejection RejectionPolicy
+}
+
+// AdaptiveExecutor 根据任务耗时自动调整并发上限的执行器
+// 任务在并发上限内立即执行，超出的任务按 Rejection 排队或拒绝；每个任务结束后用其耗时更新上限。
+// 托管阻塞（ManagedBlock）等待的是其他任务而不是下游，运行期间与托管阻塞重叠的任务不产生延迟样本，只有 panic 仍计为过载
+type AdaptiveExecutor struct {
+	exec *blockingExecutor
+	cfg  AdaptiveConfig
+
+	inflight atomic.Int64
+	limitVal atomic.Int64 // 当前上限的整数值，供无锁读取
+
+	blocking   atomic.Int64  // 进行中的托管阻塞数
+	blockEpoch atomic.Uint64 // 托管阻塞开始与结束的次数，任务运行期间发生变化说明两者重叠
+
+	mu       sync.Mutex
+	limit    float64
+	shortRTT float64 // 短期平均耗时 (ns)
+	longRTT  float64 // 长期平均耗时 (ns)
+	samples  int
+}
+
+const (
+	adaptiveShortWindow = 10
+	adaptiveLongWindow  = 100
+)
+
+// NewAdaptiveExecutor 创建自适应并发执行器
+func NewAdaptiveExecutor(cfg AdaptiveConfig) *AdaptiveExecutor {
+	if cfg.MinLimit < 1 {
+		cfg.MinLimit = 1
+	}
+	if cfg.MaxLimit <= 0 {
+		cfg.MaxLimit = 200
+	}
+	if cfg.MaxLimit < cfg.MinLimit {
+		cfg.MaxLimit = cfg.MinLimit
+	}
+	if cfg.InitialLimit <= 0 {
+		cfg.InitialLimit = 20
+	}
+	cfg.InitialLimit = min(max(cfg.InitialLimit, cfg.MinLimit), cfg.MaxLimit)
+	if cfg.BackoffRatio <= 0 || cfg.BackoffRatio >= 1 {
+		cfg.BackoffRatio = 0.9
+	}
+	if cfg.Tolerance < 1 {
+		cfg.Tolerance = 1.5
+	}
+
+	exec := NewBlockingExecutorWithPolicy(cfg.InitialLimit, cfg.Rejection).(*blockingExecutor)
+	exec.name = "adaptive"
+	e := &AdaptiveExecutor{
+		exec:  exec,
+		cfg:   cfg,
+		limit: float64(cfg.InitialLimit),
+	}
+	e.limitVal.Store(int64(cfg.InitialLimit))
+	return e
+}
+
+func (e *AdaptiveExecutor) Submit(task Runnable) {
+	_ = e.SubmitCtx(context.Background(), task)
+}
+
+func (e *AdaptiveExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	return e.exec.SubmitCtx(ctx, e.measure(task))
+}
+
+func (e *AdaptiveExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	return e.exec.TrySubmit(ctx, e.measure(task))
+}
+
+// SubmitWeighted 提交占用 weight 个并发名额的任务，语义同 blockingExecutor
+func (e *AdaptiveExecutor) SubmitWeighted(ctx context.Context, weight int, task Runnable) error {
+	return e.exec.SubmitWeighted(ctx, weight, e.measure(task))
+}
+
+// Limit 返回当前的并发上限
+func (e *AdaptiveExecutor) Limit() int {
+	return int(e.limitVal.Load())
+}
+
+// MaxConcurrency 同 Limit
+func (e *AdaptiveExecutor) MaxConcurrency() int {
+	return e.Limit()
+}
+
+// Inflight 返回正在执行的任务数
+func (e *AdaptiveExecutor) Inflight() int {
+	return int(e.inflight.Load())
+}
+
+// Stats 返回运行指标，QueuedTasks 为阻塞等待名额的提交者数量
+func (e *AdaptiveExecutor) Stats() Stats {
+	return e.exec.Stats()
+}
+
+func (e *AdaptiveExecutor) SetPanicHandler(h PanicHandler) {
+	e.exec.SetPanicHandler(h)
+}
+
+// SetTiming 只影响 Stats 中的直方图，限流算法使用的延迟样本照常采集
+func (e *AdaptiveExecutor) SetTiming(enabled bool) {
+	e.exec.SetTiming(enabled)
+}
+
+func (e *AdaptiveExecutor) Shutdown() {
+	e.exec.Shutdown()
+}
+
+func (e *AdaptiveExecutor) ShutdownNow() []Runnable {
+	return e.exec.ShutdownNow()
+}
+
+func (e *AdaptiveExecutor) AwaitTermination(ctx context.Context) error {
+	return e.exec.AwaitTermination(ctx)
+}
+
+func (e *AdaptiveExecutor) IsShutdown() bool {
+	return e.exec.IsShutdown()
+}
+
+func (e *AdaptiveExecutor) IsTerminated() bool {
+	return e.exec.IsTerminated()
+}
+
+// measure 记录任务耗时，panic 视为过载信号
+func (e *AdaptiveExecutor) measure(task Runnable) Runnable {
+	return func() {
+		inflight := e.inflight.Add(1)
+		// 先读 epoch 再读 blocking，与 beginBlocking 的先计数再推进 epoch 配合，不会漏掉开始时的阻塞
+		epoch := e.blockEpoch.Load()
+		blocked := e.blocking.Load() > 0
+		start := time.Now()
+		ok := false
+		defer func() {
+			e.inflight.Add(-1)
+			overlapped := blocked || e.blockEpoch.Load() != epoch
+			if overlapped && ok {
+				return
+			}
+			e.observe(time.Since(start), int(inflight), !ok, !overlapped)
+		}()
+		task()
+		ok = true
+	}
+}
+
+// beginBlocking 记录托管阻塞以排除重叠任务的延迟样本，补偿由底层执行器完成
+func (e *AdaptiveExecutor) beginBlocking() (int, bool) {
+	e.blocking.Add(1)
+	e.blockEpoch.Add(1)
+	return e.exec.beginBlocking()
+}
+
+func (e *AdaptiveExecutor) endBlocking(compensated bool) {
+	e.exec.endBlocking(compensated)
+	e.blockEpoch.Add(1)
+	e.blocking.Add(-1)
+}
+
+func (e *AdaptiveExecutor) executorName() string {
+	return e.exec.name
+}
+
+// observe 用一个样本更新并发上限，inflight 为任务开始时的并发数
+// sampled 为 false 时耗时不可信（与托管阻塞重叠），只用 failed 调整上限，不更新平均耗时
+func (e *AdaptiveExecutor) observe(rtt time.Duration, inflight int, failed, sampled bool) {
+	sample := float64(rtt)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if sampled {
+		e.samples++
+		if e.samples == 1 {
+			e.shortRTT, e.longRTT = sample, sample
+		} else {
+			e.shortRTT += (sample - e.shortRTT) * 2 / (adaptiveShortWindow + 1)
+			e.longRTT += (sample - e.longRTT) * 2 / (adaptiveLongWindow + 1)
+		}
+	}
+
+	var limit float64
+	switch e.cfg.Algorithm {
+	case GradientLimit:
+		limit = e.gradientLocked(inflight, failed)
+	default:
+		limit = e.aimdLocked(sample, inflight, failed)
+	}
+	limit = math.Min(math.Max(limit, float64(e.cfg.MinLimit)), float64(e.cfg.MaxLimit))
+	e.limit = limit
+
+	if n := int64(limit); n != e.limitVal.Load() {
+		e.limitVal.Store(n)
+		e.exec.SetMaxConcurrency(int(n))
+	}
+}
+
+func (e *AdaptiveExecutor) aimdLocked(sample float64, inflight int, failed bool) float64 {
+	threshold := float64(e.cfg.LatencyThreshold)
+	if threshold <= 0 {
+		threshold = 2 * e.longRTT
+	}
+	if failed || sample > threshold {
+		return e.limit * e.cfg.BackoffRatio
+	}
+	// 只在并发接近上限时增长，空闲时的低延迟不能说明更高的并发同样安全
+	// 每个样本加 1/limit，一轮约 limit 个样本合计加 1；每个样本加 1 会让上限随并发成倍增长
+	if float64(inflight)*2 >= e.limit {
+		return e.limit + 1/e.limit
+	}
+	return e.limit
+}
+
+func (e *AdaptiveExecutor) gradientLocked(inflight int, failed bool) float64 {
+	if failed {
+		return e.limit * e.cfg.BackoffRatio
+	}
+	// 长期延迟远高于短期时说明负载已下降，让长期均值更快回落
+	if e.longRTT > 2*e.shortRTT {
+		e.longRTT *= 0.95
+	}
+	// 并发远低于上限时样本不能反映上限是否合适，保持不变
+	if float64(inflight) < e.limit/2 || e.shortRTT <= 0 {
+		return e.limit
+	}
+	gradient := math.Min(math.Max(e.cfg.Tolerance*e.longRTT/e.shortRTT, 0.5), 1)
+	next := e.limit*gradient + math.Sqrt(e.limit)
+	// 平滑，避免单个样本导致剧烈抖动
+	return e.limit*0.8 + next*0.2
+}
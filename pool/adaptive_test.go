@@ -0,0 +1,120 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveExecutor_AIMD(t *testing.T) {
+	e := NewAdaptiveExecutor(AdaptiveConfig{InitialLimit: 10, MaxLimit: 20, LatencyThreshold: 10 * time.Millisecond})
+	defer e.Shutdown()
+
+	// 并发接近上限且延迟正常时每轮加 1：10 个样本后约为 11，而不是 20
+	for i := 0; i < 10; i++ {
+		e.observe(time.Millisecond, e.Limit(), false, true)
+	}
+	if e.Limit() != 10 && e.Limit() != 11 {
+		t.Fatalf("Expected limit to grow by about 1 per window, got %d", e.Limit())
+	}
+	for i := 0; i < 300; i++ {
+		e.observe(time.Millisecond, e.Limit(), false, true)
+	}
+	if e.Limit() != 20 {
+		t.Fatalf("Expected limit to grow to 20, got %d", e.Limit())
+	}
+	if e.exec.MaxConcurrency() != 20 {
+		t.Fatalf("Expected executor limit 20, got %d", e.exec.MaxConcurrency())
+	}
+
+	// 空闲时不增长
+	e.observe(time.Millisecond, 1, false, true)
+	if e.Limit() != 20 {
+		t.Fatalf("Expected limit to stay at 20, got %d", e.Limit())
+	}
+
+	// 超过阈值按比例收缩
+	e.observe(50*time.Millisecond, 20, false, true)
+	if e.Limit() != 18 {
+		t.Fatalf("Expected limit 18 after backoff, got %d", e.Limit())
+	}
+	for i := 0; i < 100; i++ {
+		e.observe(0, 20, true, true)
+	}
+	if e.Limit() != 1 {
+		t.Fatalf("Expected limit clamped to MinLimit, got %d", e.Limit())
+	}
+}
+
+func TestAdaptiveExecutor_Gradient(t *testing.T) {
+	e := NewAdaptiveExecutor(AdaptiveConfig{Algorithm: GradientLimit, InitialLimit: 20, MaxLimit: 100})
+	defer e.Shutdown()
+
+	for i := 0; i < 50; i++ {
+		e.observe(time.Millisecond, e.Limit(), false, true)
+	}
+	grown := e.Limit()
+	if grown <= 20 {
+		t.Fatalf("Expected limit to grow with stable latency, got %d", grown)
+	}
+
+	// 延迟持续上升到原来的数倍，上限随之收缩
+	for i := 0; i < 30; i++ {
+		e.observe(10*time.Millisecond, e.Limit(), false, true)
+	}
+	if e.Limit() >= grown {
+		t.Fatalf("Expected limit to shrink when latency rises, got %d (was %d)", e.Limit(), grown)
+	}
+}
+
+func TestAdaptiveExecutor_ExcludesManagedBlocking(t *testing.T) {
+	e := NewAdaptiveExecutor(AdaptiveConfig{InitialLimit: 4, MaxLimit: 4, LatencyThreshold: 10 * time.Millisecond})
+	defer e.Shutdown()
+
+	// 托管阻塞中等待的 50ms 不是下游延迟，不能触发收缩
+	done := make(chan struct{})
+	e.Submit(func() {
+		ManagedBlock(e, func() { time.Sleep(50 * time.Millisecond) })
+		close(done)
+	})
+	<-done
+	waitFor(t, func() bool { return e.Inflight() == 0 }, "Task did not finish")
+	if e.Limit() != 4 {
+		t.Fatalf("Managed blocking should not shrink the limit, got %d", e.Limit())
+	}
+	e.mu.Lock()
+	samples := e.samples
+	e.mu.Unlock()
+	if samples != 0 {
+		t.Fatalf("Expected no latency sample, got %d", samples)
+	}
+
+	// 没有托管阻塞的慢任务照常收缩
+	e.Submit(func() { time.Sleep(50 * time.Millisecond) })
+	waitFor(t, func() bool { return e.Limit() < 4 }, "Slow task should shrink the limit")
+}
+
+func TestAdaptiveExecutor_RejectsOverLimit(t *testing.T) {
+	e := NewAdaptiveExecutor(AdaptiveConfig{InitialLimit: 1, MaxLimit: 1, Rejection: AbortPolicy})
+
+	gate := make(chan struct{})
+	if err := e.SubmitCtx(context.Background(), func() { <-gate }); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return e.Inflight() == 1 }, "Task did not start")
+	if err := e.TrySubmit(context.Background(), func() {}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+	close(gate)
+
+	e.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := e.AwaitTermination(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s := e.Stats(); s.Completed != 1 || s.Rejected != 1 {
+		t.Errorf("Unexpected stats: %+v", s)
+	}
+}
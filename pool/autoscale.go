@@ -0,0 +1,140 @@
+package pool
+
+import (
+	"sync"
+	"time"
+)
+
+// ScalableExecutor 能够被 Autoscaler 调整的执行器，内置的 blockingExecutor 与 WorkerPool 均已实现
+type ScalableExecutor interface {
+	Resizable
+	StatsProvider
+}
+
+// AutoscaleConfig 自动伸缩配置
+type AutoscaleConfig struct {
+	// MinConcurrency / MaxConcurrency 并发上限的调整范围
+	MinConcurrency int
+	MaxConcurrency int
+	// Interval 采样间隔，默认 1s
+	Interval time.Duration
+	// TargetQueueWait 采样周期内平均排队时间超过该值时扩容，默认 10ms
+	TargetQueueWait time.Duration
+	// HighUtilization 利用率（运行中任务数 / 并发上限）达到该值且有任务排队时扩容，默认 0.9
+	HighUtilization float64
+	// LowUtilization 利用率持续低于该值达到 KeepAlive 后缩容，默认 0.5
+	LowUtilization float64
+	// KeepAlive 空闲容量保留多久后回收，默认 60s
+	KeepAlive time.Duration
+}
+
+// Autoscaler 根据排队时间与利用率周期性地调整执行器的并发上限
+// 扩容按倍数增长以尽快消化积压；缩容较保守，空闲持续 KeepAlive 后每次最多减半，且不低于期间观察到的峰值
+type Autoscaler struct {
+	target ScalableExecutor
+	cfg    AutoscaleConfig
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewAutoscaler 创建并启动自动伸缩器，执行器的并发上限会立即被限制到 [MinConcurrency, MaxConcurrency] 内
+// 执行器实现 Lifecycle 时，关闭后伸缩器自动停止
+func NewAutoscaler(target ScalableExecutor, cfg AutoscaleConfig) *Autoscaler {
+	if cfg.MinConcurrency < 1 {
+		cfg.MinConcurrency = 1
+	}
+	if cfg.MaxConcurrency < cfg.MinConcurrency {
+		cfg.MaxConcurrency = cfg.MinConcurrency
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.TargetQueueWait <= 0 {
+		cfg.TargetQueueWait = 10 * time.Millisecond
+	}
+	if cfg.HighUtilization <= 0 {
+		cfg.HighUtilization = 0.9
+	}
+	if cfg.LowUtilization <= 0 {
+		cfg.LowUtilization = 0.5
+	}
+	if cfg.KeepAlive <= 0 {
+		cfg.KeepAlive = 60 * time.Second
+	}
+
+	a := &Autoscaler{
+		target: target,
+		cfg:    cfg,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if limit := a.clamp(target.MaxConcurrency()); limit != target.MaxConcurrency() {
+		target.SetMaxConcurrency(limit)
+	}
+	go a.loop()
+	return a
+}
+
+// Stop 停止自动伸缩并等待后台 goroutine 退出，执行器保持当前并发上限
+func (a *Autoscaler) Stop() {
+	a.stopOnce.Do(func() { close(a.stop) })
+	<-a.done
+}
+
+func (a *Autoscaler) loop() {
+	defer close(a.done)
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	last := a.target.Stats().QueueWait
+	var idleSince time.Time
+	peak := 0
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+		}
+		if l, ok := a.target.(Lifecycle); ok && l.IsShutdown() {
+			return
+		}
+
+		s := a.target.Stats()
+		limit := a.target.MaxConcurrency()
+		util := float64(s.ActiveWorkers) / float64(limit)
+
+		var avgWait time.Duration
+		if n := s.QueueWait.Count - last.Count; n > 0 {
+			avgWait = (s.QueueWait.Sum - last.Sum) / time.Duration(n)
+		}
+		last = s.QueueWait
+
+		switch {
+		case avgWait > a.cfg.TargetQueueWait || (s.QueuedTasks > 0 && util >= a.cfg.HighUtilization):
+			idleSince = time.Time{}
+			if next := a.clamp(limit * 2); next != limit {
+				a.target.SetMaxConcurrency(next)
+			}
+		case util < a.cfg.LowUtilization:
+			now := time.Now()
+			if idleSince.IsZero() {
+				idleSince, peak = now, 0
+			}
+			peak = max(peak, s.ActiveWorkers)
+			if now.Sub(idleSince) >= a.cfg.KeepAlive {
+				if next := a.clamp(max(peak, limit/2)); next != limit {
+					a.target.SetMaxConcurrency(next)
+				}
+				idleSince = time.Time{}
+			}
+		default:
+			idleSince = time.Time{}
+		}
+	}
+}
+
+func (a *Autoscaler) clamp(n int) int {
+	return min(max(n, a.cfg.MinConcurrency), a.cfg.MaxConcurrency)
+}
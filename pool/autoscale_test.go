@@ -0,0 +1,151 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestSemaphore_CancelledHeadUnblocksOthers(t *testing.T) {
+	s := newSemaphore(2)
+	if !s.tryAcquire(2) {
+		t.Fatal("Initial acquire failed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	heavy := make(chan error, 1)
+	go func() { heavy <- s.acquire(ctx, 2) }()
+	waitFor(t, func() bool { return s.nwait.Load() == 1 }, "Heavy waiter not queued")
+
+	light := make(chan error, 1)
+	go func() { light <- s.acquire(context.Background(), 1) }()
+	waitFor(t, func() bool { return s.nwait.Load() == 2 }, "Light waiter not queued")
+
+	s.release(1)
+	select {
+	case <-light:
+		t.Fatal("Light waiter must not overtake the heavy one")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-heavy; err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if err := <-light; err != nil {
+		t.Fatalf("Light waiter should acquire after heavy one leaves, got %v", err)
+	}
+}
+
+func TestSemaphore_CloseWakesWaiters(t *testing.T) {
+	s := newSemaphore(1)
+	if !s.tryAcquire(1) {
+		t.Fatal("Initial acquire failed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 2)
+	go func() { errs <- s.acquire(ctx, 1) }()
+	go func() { errs <- s.acquire(context.Background(), 1) }()
+	waitFor(t, func() bool { return s.nwait.Load() == 2 }, "Waiters not queued")
+
+	s.close()
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != ErrShutdown {
+			t.Fatalf("Expected ErrShutdown, got %v", err)
+		}
+	}
+	if err := s.acquire(context.Background(), 1); err != ErrShutdown {
+		t.Fatalf("Acquire after close should fail, got %v", err)
+	}
+}
+
+func TestBlockingExecutor_SetMaxConcurrency(t *testing.T) {
+	exec := NewBlockingExecutor(1).(*blockingExecutor)
+	release := make(chan struct{})
+	var started int32
+	task := func() {
+		atomic.AddInt32(&started, 1)
+		<-release
+	}
+
+	exec.Submit(task)
+	go exec.Submit(task)
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&started) != 1 {
+		t.Fatalf("Only one task should run with limit 1, got %d", started)
+	}
+
+	exec.SetMaxConcurrency(2)
+	waitFor(t, func() bool { return atomic.LoadInt32(&started) == 2 }, "Growing the limit should start the waiting task")
+	if exec.MaxConcurrency() != 2 {
+		t.Errorf("Expected limit 2, got %d", exec.MaxConcurrency())
+	}
+	close(release)
+}
+
+func TestWorkerPool_SetMaxConcurrencyShrinks(t *testing.T) {
+	p := NewWorkerPool(4, 10)
+	defer p.Shutdown()
+
+	p.SetMaxConcurrency(1)
+	waitFor(t, func() bool { return p.Workers() == 1 }, "Idle workers should exit after shrinking")
+
+	var wg sync.WaitGroup
+	wg.Add(5)
+	for i := 0; i < 5; i++ {
+		p.Submit(wg.Done)
+	}
+	wg.Wait()
+	if p.Workers() != 1 {
+		t.Errorf("Pool should not grow past the new limit, got %d workers", p.Workers())
+	}
+}
+
+func TestAutoscaler_GrowAndShrink(t *testing.T) {
+	exec := NewBlockingExecutor(1).(*blockingExecutor)
+	a := NewAutoscaler(exec, AutoscaleConfig{
+		MinConcurrency:  1,
+		MaxConcurrency:  8,
+		Interval:        5 * time.Millisecond,
+		TargetQueueWait: time.Millisecond,
+		KeepAlive:       30 * time.Millisecond,
+	})
+	defer a.Stop()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				exec.Submit(func() { time.Sleep(5 * time.Millisecond) })
+			}
+		}()
+	}
+	waitFor(t, func() bool { return exec.MaxConcurrency() > 1 }, "Autoscaler should grow under load")
+	close(stop)
+	wg.Wait()
+
+	waitFor(t, func() bool { return exec.MaxConcurrency() == 1 }, "Autoscaler should shrink back to MinConcurrency when idle")
+}
@@ -0,0 +1,53 @@
+package pool
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// 对比每个任务一个 goroutine 的 blockingExecutor 与复用 worker 的 WorkerPool
+// BlockingExecutor 系列覆盖 SubmitCtx 的各条路径：无竞争的快速路径、关闭耗时统计、加权提交与排队等待，
+// 修改信号量或提交路径时对比前后结果，避免提交开销悄悄变大
+
+func benchmarkExecutor(b *testing.B, exec Executor) {
+	var wg sync.WaitGroup
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			wg.Add(1)
+			exec.Submit(func() {
+				wg.Done()
+			})
+		}
+	})
+	wg.Wait()
+}
+
+func BenchmarkBlockingExecutor(b *testing.B) {
+	benchmarkExecutor(b, NewBlockingExecutor(runtime.NumCPU()*2))
+}
+
+func BenchmarkBlockingExecutor_NoTiming(b *testing.B) {
+	exec := NewBlockingExecutor(runtime.NumCPU() * 2)
+	exec.(TimingSetter).SetTiming(false)
+	benchmarkExecutor(b, exec)
+}
+
+func BenchmarkBlockingExecutor_Weighted(b *testing.B) {
+	benchmarkExecutor(b, Weighted(NewBlockingExecutor(runtime.NumCPU()*2), 2))
+}
+
+// 并发上限为 1，几乎每次提交都要排队等待信号量
+func BenchmarkBlockingExecutor_Contended(b *testing.B) {
+	benchmarkExecutor(b, NewBlockingExecutor(1))
+}
+
+func BenchmarkWorkerPool(b *testing.B) {
+	benchmarkExecutor(b, NewWorkerPool(runtime.NumCPU()*2, 1024))
+}
+
+func BenchmarkWorkerPool_Unbuffered(b *testing.B) {
+	benchmarkExecutor(b, NewWorkerPool(runtime.NumCPU()*2, 0))
+}
@@ -0,0 +1,253 @@
+package pool
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// ============ 托管阻塞 (Managed Blocking) ============
+//
+// 任务在 worker 上阻塞等待（如 Join 另一个 Future）时仍然占用执行器的一个并发名额，
+// 多个嵌套等待就可能耗尽整个池导致饥饿甚至死锁。
+// 托管阻塞需要显式声明：任务通过 ManagedBlock(exec, wait) 或 future 的 JoinManaged / GetManaged 告知 exec 自己即将阻塞，
+// 执行器在阻塞期间临时补偿一个名额（blockingExecutor 放宽信号量，WorkerPool 与固定 worker 数的执行器多启动一个 worker），
+// 阻塞结束后收回。ForkJoinPool 与 LockedThreadExecutor 只报告阻塞而不补偿，见各自的说明。
+// 普通的 Join / Get 不做任何检查，没有额外开销。
+
+// blockManager 支持托管阻塞的执行器
+type blockManager interface {
+	// beginBlocking 登记一个阻塞中的 worker，补偿数未达到上限时补偿一个名额，返回阻塞中的 worker 数与是否补偿
+	beginBlocking() (blocked int, compensated bool)
+	// endBlocking 结束阻塞，compensated 为 beginBlocking 的返回值
+	endBlocking(compensated bool)
+	executorName() string
+}
+
+// DefaultMaxSpare 每个执行器默认最多同时补偿的名额数，与 Java ForkJoinPool 的 maximumSpares 一致
+const DefaultMaxSpare = 256
+
+var maxSpare atomic.Int32
+
+func init() {
+	maxSpare.Store(DefaultMaxSpare)
+}
+
+// SetMaxSpare 设置每个执行器最多同时补偿的名额数，n < 0 时按 0 处理（不再补偿）
+// 超过上限的托管阻塞不再补偿，只报告给 SetBlockingDebug，避免过深的嵌套等待无限制地创建 goroutine
+func SetMaxSpare(n int) {
+	maxSpare.Store(int32(max(n, 0)))
+}
+
+// blockCounter 无锁执行器的托管阻塞计数
+type blockCounter struct {
+	blocked atomic.Int32 // 阻塞中的 worker 数
+	spare   atomic.Int32 // 其中得到补偿的数量
+}
+
+// enter 登记一个阻塞，compensate 为 true 且补偿数未达到上限时占用一个补偿名额
+func (c *blockCounter) enter(compensate bool) (int, bool) {
+	n := int(c.blocked.Add(1))
+	if !compensate {
+		return n, false
+	}
+	limit := maxSpare.Load()
+	for {
+		s := c.spare.Load()
+		if s >= limit {
+			return n, false
+		}
+		if c.spare.CompareAndSwap(s, s+1) {
+			return n, true
+		}
+	}
+}
+
+func (c *blockCounter) exit(compensated bool) {
+	if compensated {
+		c.spare.Add(-1)
+	}
+	c.blocked.Add(-1)
+}
+
+// fixedPool 固定 worker 数执行器（PriorityExecutor、FairShareExecutor、DeadlineExecutor）共用的 worker 管理，
+// 计数受执行器的 lifecycle.mu 保护，空闲的 worker 在 cond 上等待。
+// 托管阻塞期间多启动一个 worker，阻塞结束后多出的 worker 在下次取任务前退出
+type fixedPool struct {
+	cond    *sync.Cond
+	work    func()
+	workers int // 配置的 worker 数
+	alive   int
+	blocked int
+	spare   int // 为托管阻塞补充的 worker 数
+}
+
+// start 启动 workers 个运行 work 的 worker，mu 为执行器的 lifecycle.mu
+func (p *fixedPool) start(mu *sync.RWMutex, workers int, work func()) {
+	p.cond = sync.NewCond(mu)
+	p.work = work
+	p.workers = workers
+	p.alive = workers
+	for i := 0; i < workers; i++ {
+		go work()
+	}
+}
+
+// retireLocked 存活的 worker 多于 workers + spare 时让当前 worker 退出
+// 此时至少还有 workers 个 worker，无需检查终止
+func (p *fixedPool) retireLocked() bool {
+	if p.alive > p.workers+p.spare {
+		p.alive--
+		return true
+	}
+	return false
+}
+
+func (p *fixedPool) beginBlocking() (int, bool) {
+	p.cond.L.Lock()
+	p.blocked++
+	n := p.blocked
+	ok := p.spare < int(maxSpare.Load())
+	if ok {
+		p.spare++
+		p.alive++
+	}
+	p.cond.L.Unlock()
+	if ok {
+		go p.work()
+	}
+	return n, ok
+}
+
+// endBlocking 唤醒空闲的 worker，多出的 worker 随之退出
+func (p *fixedPool) endBlocking(compensated bool) {
+	p.cond.L.Lock()
+	p.blocked--
+	if compensated {
+		p.spare--
+	}
+	p.cond.L.Unlock()
+	if compensated {
+		p.cond.Broadcast()
+	}
+}
+
+// ManagedBlock 在 exec 的任务中执行可能长时间阻塞的 wait，阻塞期间 exec 补偿一个并发名额，避免嵌套等待耗尽执行器
+// 每个执行器同时补偿的名额不超过 SetMaxSpare 设置的上限，超过后 wait 照常执行但不再补偿。
+// exec 可以是 Chain、MemoryGuard 等包装后的执行器，或 Worker、Tenant、Weighted 等视图；
+// exec 为 nil 或不支持托管阻塞时直接执行 wait。
+// 只应在 exec 的任务中调用，在其他 goroutine 上调用时补偿的名额会让 exec 暂时超出并发上限
+func ManagedBlock(exec Executor, wait func()) {
+	m := blockManagerOf(exec)
+	if m == nil {
+		wait()
+		return
+	}
+	blocked, compensated := m.beginBlocking()
+	defer m.endBlocking(compensated)
+	if h := blockingDebug.Load(); h != nil {
+		reportBlocking(*h, BlockingInfo{
+			Executor:    m.executorName(),
+			Blocked:     blocked,
+			Compensated: compensated,
+			Stack:       debug.Stack(),
+		})
+	}
+	wait()
+}
+
+// blockManagerOf 沿包装与视图找到实际运行任务的执行器
+func blockManagerOf(exec Executor) blockManager {
+	for exec != nil {
+		switch e := exec.(type) {
+		case blockManager:
+			return e
+		case interface{ Unwrap() Executor }:
+			exec = e.Unwrap()
+		case *RateLimitedExecutor:
+			exec = e.base
+		case keyedView:
+			exec = e.e.base
+		case tenantView:
+			exec = e.e
+		case lockedView:
+			exec = e.w.e
+		case weightedView:
+			exec = e.exec
+			if exec == nil {
+				exec = Global()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// ============ 调试模式 ============
+
+// BlockingInfo 调试模式下 worker 阻塞时报告的信息
+type BlockingInfo struct {
+	// Executor 执行器类型
+	Executor string
+	// Blocked 该执行器当前阻塞中的 worker 数（包括本次），大于 1 说明存在多个嵌套等待
+	Blocked int
+	// Compensated 执行器是否为本次阻塞补偿了名额，只报告的执行器或补偿数达到 SetMaxSpare 的上限时为 false
+	Compensated bool
+	// Stack 发生阻塞的调用栈
+	Stack []byte
+}
+
+// BlockingHandler 处理调试模式下检测到的 worker 阻塞
+type BlockingHandler func(info BlockingInfo)
+
+var blockingDebug atomic.Pointer[BlockingHandler]
+
+// SetBlockingDebug 开启调试模式，每次托管阻塞（ManagedBlock、future 的 JoinManaged / GetManaged）都会报告给 h
+// 传入 nil 关闭调试模式，默认关闭
+func SetBlockingDebug(h BlockingHandler) {
+	if h == nil {
+		blockingDebug.Store(nil)
+		return
+	}
+	blockingDebug.Store(&h)
+}
+
+// SlogBlockingHandler 返回以 Warn 级别写入 logger 的处理器，logger 为 nil 时使用 slog.Default()
+func SlogBlockingHandler(logger *slog.Logger) BlockingHandler {
+	return func(info BlockingInfo) {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		l.WarnContext(context.Background(), "[Pool] Blocking wait inside worker",
+			slog.String("executor", info.Executor),
+			slog.Int("blocked", info.Blocked),
+			slog.Bool("compensated", info.Compensated),
+			slog.String("stack", string(info.Stack)),
+		)
+	}
+}
+
+func reportBlocking(h BlockingHandler, info BlockingInfo) {
+	defer func() { _ = recover() }()
+	h(info)
+}
+
+func (m *metrics) executorName() string {
+	return m.name
+}
+
+// 托管阻塞按类型断言查找 blockManager，签名变化时编译器不会报错，在这里静态检查
+var (
+	_ blockManager = (*blockingExecutor)(nil)
+	_ blockManager = (*AdaptiveExecutor)(nil)
+	_ blockManager = (*WorkerPool)(nil)
+	_ blockManager = (*PriorityExecutor)(nil)
+	_ blockManager = (*FairShareExecutor)(nil)
+	_ blockManager = (*DeadlineExecutor)(nil)
+	_ blockManager = (*ForkJoinPool)(nil)
+	_ blockManager = (*LockedThreadExecutor)(nil)
+)
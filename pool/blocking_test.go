@@ -0,0 +1,237 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitClosed(t *testing.T, ch <-chan struct{}, msg string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(3 * time.Second):
+		t.Fatal(msg)
+	}
+}
+
+func TestManagedBlock_NestedSubmitOnBlockingExecutor(t *testing.T) {
+	exec := NewBlockingExecutor(1)
+	done := make(chan struct{})
+	exec.Submit(func() {
+		inner := make(chan struct{})
+		// 唯一的名额被当前任务占用，嵌套提交与等待都需要补偿
+		ManagedBlock(exec, func() {
+			exec.Submit(func() { close(inner) })
+			<-inner
+		})
+		close(done)
+	})
+	waitClosed(t, done, "Nested task starved on a single-slot executor")
+}
+
+func TestManagedBlock_NestedSubmitOnWorkerPool(t *testing.T) {
+	p := NewWorkerPool(1, 0)
+	defer p.Shutdown()
+
+	done := make(chan struct{})
+	p.Submit(func() {
+		inner := make(chan struct{})
+		// 没有队列，嵌套提交需要补偿的 worker 接收
+		ManagedBlock(p, func() {
+			p.Submit(func() { close(inner) })
+			<-inner
+		})
+		close(done)
+	})
+	waitClosed(t, done, "Nested task starved on a single-worker pool")
+	waitFor(t, func() bool { return p.Workers() == 1 }, "Compensating worker did not retire")
+}
+
+func TestManagedBlock_ThroughWrappers(t *testing.T) {
+	exec := NewBlockingExecutor(1)
+	wrapped := map[string]Executor{
+		"chain":    Chain(exec),
+		"weighted": Weighted(exec, 1),
+		"guard":    NewMemoryGuard(exec, MemoryGuardConfig{}),
+	}
+	for name, w := range wrapped {
+		t.Run(name, func(t *testing.T) {
+			done := make(chan struct{})
+			w.Submit(func() {
+				inner := make(chan struct{})
+				ManagedBlock(w, func() {
+					w.Submit(func() { close(inner) })
+					<-inner
+				})
+				close(done)
+			})
+			waitClosed(t, done, "Wrapped executor was not compensated")
+		})
+	}
+}
+
+func TestSetBlockingDebug(t *testing.T) {
+	var mu sync.Mutex
+	var infos []BlockingInfo
+	SetBlockingDebug(func(info BlockingInfo) {
+		mu.Lock()
+		infos = append(infos, info)
+		mu.Unlock()
+	})
+	defer SetBlockingDebug(nil)
+
+	// 不支持托管阻塞的执行器不报告
+	ManagedBlock(nil, func() {})
+	ManagedBlock(&DirectExecutor{}, func() {})
+
+	exec := NewBlockingExecutor(2)
+	done := make(chan struct{})
+	exec.Submit(func() {
+		inner := make(chan struct{})
+		exec.Submit(func() {
+			ManagedBlock(exec, func() { time.Sleep(5 * time.Millisecond) })
+			close(inner)
+		})
+		ManagedBlock(exec, func() { <-inner })
+		close(done)
+	})
+	waitClosed(t, done, "Tasks did not finish")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 reports, got %d", len(infos))
+	}
+	nested := false
+	for _, info := range infos {
+		if info.Executor != "blocking" {
+			t.Errorf("Unexpected executor %q", info.Executor)
+		}
+		if len(info.Stack) == 0 {
+			t.Error("Expected stack")
+		}
+		if info.Blocked == 2 {
+			nested = true
+		}
+	}
+	if !nested {
+		t.Error("Expected the inner wait to observe 2 blocked workers")
+	}
+}
+
+func TestManagedBlock_FixedWorkerExecutors(t *testing.T) {
+	executors := map[string]interface {
+		ManagedExecutor
+		StatsProvider
+	}{
+		"priority":  NewPriorityExecutor(1, 0),
+		"fairshare": NewFairShareExecutor(1, TenantConfig{}),
+		"deadline":  NewDeadlineExecutor(1),
+	}
+	for name, e := range executors {
+		t.Run(name, func(t *testing.T) {
+			done := make(chan struct{})
+			e.Submit(func() {
+				inner := make(chan struct{})
+				e.Submit(func() { close(inner) })
+				ManagedBlock(e, func() { <-inner })
+				close(done)
+			})
+			waitClosed(t, done, "Nested task starved on a single-worker executor")
+			waitFor(t, func() bool { return e.Stats().Workers == 1 }, "Compensating worker did not retire")
+
+			e.Shutdown()
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			if err := e.AwaitTermination(ctx); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestManagedBlock_ReportOnlyExecutors(t *testing.T) {
+	var mu sync.Mutex
+	var names []string
+	SetBlockingDebug(func(info BlockingInfo) {
+		mu.Lock()
+		names = append(names, info.Executor)
+		mu.Unlock()
+	})
+	defer SetBlockingDebug(nil)
+
+	fj := NewForkJoinPool(1)
+	defer fj.Shutdown()
+	locked := NewLockedThreadExecutor(1)
+	defer locked.Shutdown()
+
+	for _, e := range []Executor{fj, locked} {
+		done := make(chan struct{})
+		e.Submit(func() {
+			ManagedBlock(e, func() {})
+			close(done)
+		})
+		waitClosed(t, done, "Task did not finish")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(names) != 2 || names[0] != "forkjoin" || names[1] != "locked" {
+		t.Errorf("Expected reports from forkjoin and locked, got %v", names)
+	}
+}
+
+func TestManagedBlock_MaxSpare(t *testing.T) {
+	SetMaxSpare(2)
+	defer SetMaxSpare(DefaultMaxSpare)
+	var mu sync.Mutex
+	var infos []BlockingInfo
+	SetBlockingDebug(func(info BlockingInfo) {
+		mu.Lock()
+		infos = append(infos, info)
+		mu.Unlock()
+	})
+	defer SetBlockingDebug(nil)
+
+	e := NewPriorityExecutor(1, 0)
+	defer e.Shutdown()
+
+	// 三层嵌套等待，只有前两层得到补偿，最内层等待测试放行
+	release := make(chan struct{})
+	var nested func(depth int)
+	nested = func(depth int) {
+		ManagedBlock(e, func() {
+			if depth == 0 {
+				<-release
+				return
+			}
+			done := make(chan struct{})
+			e.Submit(func() { nested(depth - 1); close(done) })
+			<-done
+		})
+	}
+	finished := make(chan struct{})
+	e.Submit(func() { nested(2); close(finished) })
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(infos) == 3
+	}, "Expected 3 blocking reports")
+	if w := e.Stats().Workers; w != 3 {
+		t.Errorf("Expected 1 worker plus 2 spares, got %d", w)
+	}
+	close(release)
+	waitClosed(t, finished, "Nested waits did not finish")
+
+	mu.Lock()
+	for i, info := range infos {
+		if want := i < 2; info.Compensated != want {
+			t.Errorf("Report %d: expected compensated=%v, got %+v", i, want, info)
+		}
+	}
+	mu.Unlock()
+	waitFor(t, func() bool { return e.Stats().Workers == 1 }, "Spare workers did not retire")
+}
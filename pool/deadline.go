@@ -0,0 +1,254 @@
+package pool
+
+import (
+	"container/heap"
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// DeadlineExecutor 按 context 截止时间调度的执行器 (Earliest Deadline First)
+// 固定数量的 worker 总是先执行截止时间最早的任务，没有截止时间的任务排在所有有截止时间的任务之后，按提交顺序执行。
+// 截止时间已过的任务不会再执行：提交时、轮到执行时以及排队期间到期时都会被丢弃（shed），
+// 并以 ctx 的错误通知拒绝回调，通过 future 提交的任务因此以 context.DeadlineExceeded 失败。
+type DeadlineExecutor struct {
+	lifecycle
+	metrics
+	fixedPool
+	queue deadlineQueue
+	seq   uint64
+
+	sweep   *time.Timer // 在队首任务到期时清理队列
+	sweepAt time.Time
+
+	shed atomic.Uint64
+}
+
+// NewDeadlineExecutor 创建 workers 个 worker 的 EDF 执行器
+func NewDeadlineExecutor(workers int) *DeadlineExecutor {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	e := &DeadlineExecutor{
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "deadline"},
+	}
+	e.sweep = time.AfterFunc(time.Hour, e.shedExpired)
+	e.sweep.Stop()
+	e.start(&e.mu, workers, e.work)
+	return e
+}
+
+// Submit 提交没有截止时间的任务
+func (e *DeadlineExecutor) Submit(task Runnable) {
+	_ = e.SubmitCtx(context.Background(), task)
+}
+
+// SubmitCtx 以 ctx 的截止时间提交任务，截止时间已过时直接丢弃并返回 ctx 的错误
+func (e *DeadlineExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now()
+	if err := expired(ctx, now); err != nil {
+		e.shed.Add(1)
+		e.rejected.Add(1)
+		return err
+	}
+	deadline, hasDeadline := ctx.Deadline()
+
+	e.mu.Lock()
+	if e.shutdown {
+		e.mu.Unlock()
+		e.rejected.Add(1)
+		return ErrShutdown
+	}
+	e.seq++
+	heap.Push(&e.queue, &deadlineTask{
+		job:         job{ctx: ctx, task: task, enqueued: now},
+		deadline:    deadline,
+		hasDeadline: hasDeadline,
+		seq:         e.seq,
+	})
+	e.scheduleSweepLocked()
+	e.mu.Unlock()
+
+	e.submitted.Add(1)
+	e.cond.Signal()
+	return nil
+}
+
+// TrySubmit 队列无界，除关闭与截止时间已过外不会拒绝，等同于 SubmitCtx
+func (e *DeadlineExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	return e.SubmitCtx(ctx, task)
+}
+
+// Shed 返回因截止时间已过或 ctx 结束而被丢弃的任务数
+func (e *DeadlineExecutor) Shed() uint64 {
+	return e.shed.Load()
+}
+
+// Stats 返回运行指标，Rejected 包含 Shed
+func (e *DeadlineExecutor) Stats() Stats {
+	s := e.snapshot()
+	e.mu.RLock()
+	s.Workers = e.alive
+	s.QueuedTasks = e.queue.Len()
+	e.mu.RUnlock()
+	return s
+}
+
+// Shutdown 停止接受新任务，队列中的任务按截止时间执行完后 worker 退出
+func (e *DeadlineExecutor) Shutdown() {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	e.mu.Unlock()
+	e.cond.Broadcast()
+}
+
+// ShutdownNow 停止接受新任务，按截止时间顺序返回尚未开始的任务
+func (e *DeadlineExecutor) ShutdownNow() []Runnable {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	var jobs []job
+	for e.queue.Len() > 0 {
+		jobs = append(jobs, heap.Pop(&e.queue).(*deadlineTask).job)
+	}
+	e.sweep.Stop()
+	e.mu.Unlock()
+	e.cond.Broadcast()
+
+	e.rejected.Add(uint64(len(jobs)))
+	pending := make([]Runnable, 0, len(jobs))
+	for _, j := range jobs {
+		NotifyRejected(j.ctx, ErrShutdown)
+		pending = append(pending, j.task)
+	}
+	return pending
+}
+
+func (e *DeadlineExecutor) work() {
+	for {
+		e.mu.Lock()
+		for {
+			if e.retireLocked() {
+				e.mu.Unlock()
+				return
+			}
+			if e.queue.Len() > 0 || e.shutdown {
+				break
+			}
+			e.cond.Wait()
+		}
+		if e.queue.Len() == 0 {
+			// 已关闭且队列为空
+			e.alive--
+			if e.alive == 0 {
+				e.sweep.Stop()
+				e.terminate()
+			}
+			e.mu.Unlock()
+			return
+		}
+		j := heap.Pop(&e.queue).(*deadlineTask).job
+		e.scheduleSweepLocked()
+		e.mu.Unlock()
+
+		if err := expired(j.ctx, time.Now()); err != nil {
+			e.drop(j, err)
+			continue
+		}
+		e.metrics.run(j.ctx, j.task, j.enqueued)
+	}
+}
+
+// scheduleSweepLocked 让清理计时器在队首任务的截止时间触发
+func (e *DeadlineExecutor) scheduleSweepLocked() {
+	if e.queue.Len() == 0 || !e.queue[0].hasDeadline {
+		e.sweep.Stop()
+		e.sweepAt = time.Time{}
+		return
+	}
+	at := e.queue[0].deadline
+	if at.Equal(e.sweepAt) {
+		return
+	}
+	e.sweepAt = at
+	e.sweep.Reset(time.Until(at))
+}
+
+// shedExpired 丢弃队首所有已到期的任务，worker 都在忙时也能及时通知
+func (e *DeadlineExecutor) shedExpired() {
+	now := time.Now()
+	var jobs []job
+	e.mu.Lock()
+	for e.queue.Len() > 0 {
+		head := e.queue[0]
+		if !head.hasDeadline || now.Before(head.deadline) {
+			break
+		}
+		jobs = append(jobs, heap.Pop(&e.queue).(*deadlineTask).job)
+	}
+	e.sweepAt = time.Time{}
+	e.scheduleSweepLocked()
+	e.mu.Unlock()
+
+	for _, j := range jobs {
+		e.drop(j, context.DeadlineExceeded)
+	}
+}
+
+func (e *DeadlineExecutor) drop(j job, err error) {
+	e.shed.Add(1)
+	e.rejected.Add(1)
+	NotifyRejected(j.ctx, err)
+}
+
+// expired 返回 ctx 的错误；ctx 尚未感知但截止时间已过时返回 context.DeadlineExceeded
+func expired(ctx context.Context, now time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && !now.Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// ============ 截止时间队列 ============
+
+type deadlineTask struct {
+	job
+	deadline    time.Time
+	hasDeadline bool
+	seq         uint64 // 截止时间相同或都没有截止时间时先进先出
+}
+
+type deadlineQueue []*deadlineTask
+
+func (q deadlineQueue) Len() int { return len(q) }
+
+func (q deadlineQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.hasDeadline != b.hasDeadline {
+		return a.hasDeadline
+	}
+	if a.hasDeadline && !a.deadline.Equal(b.deadline) {
+		return a.deadline.Before(b.deadline)
+	}
+	return a.seq < b.seq
+}
+
+func (q deadlineQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deadlineQueue) Push(x any) { *q = append(*q, x.(*deadlineTask)) }
+
+func (q *deadlineQueue) Pop() any {
+	old := *q
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return t
+}
@@ -0,0 +1,119 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDeadlineExecutor_EarliestDeadlineFirst(t *testing.T) {
+	e := NewDeadlineExecutor(1)
+	defer e.Shutdown()
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-gate })
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	submit := func(name string, ctx context.Context) {
+		wg.Add(1)
+		_ = e.SubmitCtx(ctx, func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		})
+	}
+	now := time.Now()
+	for _, d := range []struct {
+		name string
+		in   time.Duration
+	}{{"3s", 3 * time.Second}, {"none", 0}, {"1s", time.Second}, {"2s", 2 * time.Second}} {
+		ctx := context.Background()
+		if d.in > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, now.Add(d.in))
+			defer cancel()
+		}
+		submit(d.name, ctx)
+	}
+	close(gate)
+	wg.Wait()
+
+	want := []string{"1s", "2s", "3s", "none"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestDeadlineExecutor_ShedsExpiredTasks(t *testing.T) {
+	e := NewDeadlineExecutor(1)
+	defer e.Shutdown()
+
+	past, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if err := e.SubmitCtx(past, func() { t.Error("Expired task must not run") }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-gate })
+	<-started
+	defer close(gate)
+
+	// worker 一直忙碌，排队中的任务到期后仍会被及时丢弃并通知
+	shed := make(chan error, 1)
+	ctx, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	ctx = WithRejectHandler(ctx, func(err error) { shed <- err })
+	if err := e.SubmitCtx(ctx, func() { t.Error("Expired task must not run") }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-shed:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Queued task was not shed after its deadline")
+	}
+
+	if e.Shed() != 2 {
+		t.Errorf("Expected 2 shed tasks, got %d", e.Shed())
+	}
+	if s := e.Stats(); s.Rejected != 2 || s.QueuedTasks != 0 {
+		t.Errorf("Unexpected stats: %+v", s)
+	}
+}
+
+func TestDeadlineExecutor_ShutdownNow(t *testing.T) {
+	e := NewDeadlineExecutor(1)
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-gate })
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_ = e.SubmitCtx(ctx, func() {})
+	e.Submit(func() {})
+	if pending := e.ShutdownNow(); len(pending) != 2 {
+		t.Fatalf("Expected 2 pending tasks, got %d", len(pending))
+	}
+	close(gate)
+
+	wait, cancel2 := context.WithTimeout(context.Background(), time.Second)
+	defer cancel2()
+	if err := e.AwaitTermination(wait); err != nil {
+		t.Fatal(err)
+	}
+}
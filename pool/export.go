@@ -0,0 +1,96 @@
+package pool
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PublishExpvar 以 name 发布执行器指标到 expvar（/debug/vars），name 重复时 panic（与 expvar.Publish 一致）
+func PublishExpvar(name string, p StatsProvider) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return p.Stats()
+	}))
+}
+
+// PrometheusHandler 返回以 Prometheus 文本格式输出指标的 http.Handler
+// providers 的 key 作为 executor 标签的值
+func PrometheusHandler(providers map[string]StatsProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		stats := make(map[string]Stats, len(providers))
+		for name, p := range providers {
+			stats[name] = p.Stats()
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WritePrometheus(w, stats)
+	})
+}
+
+// WritePrometheus 将指标以 Prometheus 文本格式写入 w
+func WritePrometheus(w io.Writer, stats map[string]Stats) error {
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	gauge := func(metric, help string, value func(Stats) float64) {
+		writeHeader(bw, metric, help, "gauge")
+		for _, name := range names {
+			writeSample(bw, metric, name, "", value(stats[name]))
+		}
+	}
+	counter := func(metric, help string, value func(Stats) uint64) {
+		writeHeader(bw, metric, help, "counter")
+		for _, name := range names {
+			writeSample(bw, metric, name, "", float64(value(stats[name])))
+		}
+	}
+	histogram := func(metric, help string, value func(Stats) Histogram) {
+		writeHeader(bw, metric, help, "histogram")
+		for _, name := range names {
+			h := value(stats[name])
+			for i, upper := range h.Buckets {
+				le := strconv.FormatFloat(upper.Seconds(), 'g', -1, 64)
+				writeSample(bw, metric+"_bucket", name, le, float64(h.Counts[i]))
+			}
+			writeSample(bw, metric+"_bucket", name, "+Inf", float64(h.Count))
+			writeSample(bw, metric+"_sum", name, "", h.Sum.Seconds())
+			writeSample(bw, metric+"_count", name, "", float64(h.Count))
+		}
+	}
+
+	gauge("gofuture_pool_workers", "Current number of worker goroutines.", func(s Stats) float64 { return float64(s.Workers) })
+	gauge("gofuture_pool_active_workers", "Number of tasks currently running.", func(s Stats) float64 { return float64(s.ActiveWorkers) })
+	gauge("gofuture_pool_queued_tasks", "Number of accepted tasks waiting to start.", func(s Stats) float64 { return float64(s.QueuedTasks) })
+	counter("gofuture_pool_submitted_total", "Total number of accepted tasks.", func(s Stats) uint64 { return s.Submitted })
+	counter("gofuture_pool_completed_total", "Total number of tasks that finished normally.", func(s Stats) uint64 { return s.Completed })
+	counter("gofuture_pool_panicked_total", "Total number of tasks that panicked.", func(s Stats) uint64 { return s.Panicked })
+	counter("gofuture_pool_rejected_total", "Total number of rejected or discarded tasks.", func(s Stats) uint64 { return s.Rejected })
+	histogram("gofuture_pool_queue_wait_seconds", "Time tasks spent waiting before they started.", func(s Stats) Histogram { return s.QueueWait })
+	histogram("gofuture_pool_run_seconds", "Time tasks spent running.", func(s Stats) Histogram { return s.RunTime })
+
+	return bw.Flush()
+}
+
+func writeHeader(w *bufio.Writer, metric, help, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric, help, metric, typ)
+}
+
+// labelEscaper 按 Prometheus 文本格式转义标签值
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func writeSample(w *bufio.Writer, metric, executor, le string, value float64) {
+	v := strconv.FormatFloat(value, 'g', -1, 64)
+	if le == "" {
+		fmt.Fprintf(w, "%s{executor=\"%s\"} %s\n", metric, labelEscaper.Replace(executor), v)
+		return
+	}
+	fmt.Fprintf(w, "%s{executor=\"%s\",le=\"%s\"} %s\n", metric, labelEscaper.Replace(executor), le, v)
+}
@@ -0,0 +1,362 @@
+package pool
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+type tenantKey struct{}
+
+// WithTenant 返回携带租户 key 的 context，通过 SubmitCtx 提交到 FairShareExecutor 的任务归属该租户
+func WithTenant(ctx context.Context, tenant any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom 读取 ctx 上的租户 key，未设置时返回 nil（默认租户）
+func TenantFrom(ctx context.Context) any {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Value(tenantKey{})
+}
+
+// TenantConfig 租户配置
+type TenantConfig struct {
+	// Weight 每轮调度可以启动的任务数，即该租户获得的份额，默认 1
+	Weight int
+	// MaxConcurrency 同时运行的任务上限，0 表示只受 worker 数限制
+	MaxConcurrency int
+	// MaxQueued 排队任务上限，超出时返回 ErrRejected，0 表示不限制
+	MaxQueued int
+}
+
+// TenantStats 租户运行指标快照
+type TenantStats struct {
+	TenantConfig
+	// Running 正在执行的任务数
+	Running int
+	// Queued 排队中的任务数
+	Queued    int
+	Submitted uint64
+	Completed uint64
+	Panicked  uint64
+	Rejected  uint64
+	// QueueWait 任务从提交到开始执行的等待时间分布
+	QueueWait Histogram
+}
+
+// FairShareExecutor 多租户公平调度执行器
+// 每个租户有独立的队列，固定数量的 worker 按加权差额轮询 (Deficit Round-Robin) 在租户间调度：
+// 每轮每个有积压的租户最多启动 Weight 个任务，达到 MaxConcurrency 的租户本轮跳过，
+// 因此单个租户提交再多任务也只能占用与权重相称的份额，不会挤满所有 worker。
+// 租户 key 必须是可比较的类型，租户在第一次提交时创建，其配置与指标会一直保留。
+type FairShareExecutor struct {
+	lifecycle
+	metrics
+	fixedPool
+	defaults TenantConfig
+	tenants  map[any]*tenant
+	ring     []*tenant // 有排队任务的租户
+	cursor   int
+	queued   int
+}
+
+type tenant struct {
+	key     any
+	cfg     TenantConfig
+	queue   []job
+	deficit int  // 本轮剩余可启动的任务数
+	inRing  bool // 是否在调度环中
+	running int
+
+	submitted uint64
+	completed uint64
+	panicked  uint64
+	rejected  uint64
+	queueWait histogram
+}
+
+// NewFairShareExecutor 创建 workers 个 worker 的公平调度执行器，defaults 为未单独配置的租户的配置
+func NewFairShareExecutor(workers int, defaults TenantConfig) *FairShareExecutor {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	e := &FairShareExecutor{
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "fairshare"},
+		defaults:  normalizeTenant(defaults),
+		tenants:   make(map[any]*tenant),
+	}
+	e.start(&e.mu, workers, e.work)
+	return e
+}
+
+func normalizeTenant(cfg TenantConfig) TenantConfig {
+	if cfg.Weight < 1 {
+		cfg.Weight = 1
+	}
+	if cfg.MaxConcurrency < 0 {
+		cfg.MaxConcurrency = 0
+	}
+	if cfg.MaxQueued < 0 {
+		cfg.MaxQueued = 0
+	}
+	return cfg
+}
+
+// SetTenant 设置租户的配置，对已排队的任务立即生效
+func (e *FairShareExecutor) SetTenant(key any, cfg TenantConfig) {
+	e.mu.Lock()
+	t := e.tenantLocked(key)
+	t.cfg = normalizeTenant(cfg)
+	t.deficit = min(t.deficit, t.cfg.Weight)
+	e.mu.Unlock()
+	// 并发上限可能被调高
+	e.cond.Broadcast()
+}
+
+// Submit 以默认租户 (nil) 提交任务
+func (e *FairShareExecutor) Submit(task Runnable) {
+	_ = e.SubmitTenant(context.Background(), nil, task)
+}
+
+// SubmitCtx 以 ctx 上的租户 (WithTenant) 提交任务，开始前 ctx 已结束的任务会被丢弃
+func (e *FairShareExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	return e.SubmitTenant(ctx, TenantFrom(ctx), task)
+}
+
+// TrySubmit 除租户排队数超过 MaxQueued 与关闭外不会拒绝，等同于 SubmitCtx
+func (e *FairShareExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	return e.SubmitCtx(ctx, task)
+}
+
+// SubmitTenant 以指定租户提交任务
+func (e *FairShareExecutor) SubmitTenant(ctx context.Context, key any, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now()
+
+	e.mu.Lock()
+	if e.shutdown {
+		e.mu.Unlock()
+		e.rejected.Add(1)
+		return ErrShutdown
+	}
+	t := e.tenantLocked(key)
+	if t.cfg.MaxQueued > 0 && len(t.queue) >= t.cfg.MaxQueued {
+		t.rejected++
+		e.mu.Unlock()
+		e.rejected.Add(1)
+		return ErrRejected
+	}
+	t.queue = append(t.queue, job{ctx: ctx, task: task, enqueued: now})
+	t.submitted++
+	e.queued++
+	if !t.inRing {
+		t.inRing = true
+		e.ring = append(e.ring, t)
+	}
+	e.mu.Unlock()
+
+	e.submitted.Add(1)
+	e.cond.Signal()
+	return nil
+}
+
+// ForTenant 返回绑定到租户的 Executor 视图，可直接传给 future 的 *WithExecutor 系列函数
+func (e *FairShareExecutor) ForTenant(key any) ContextExecutor {
+	return tenantView{e: e, key: key}
+}
+
+// TenantStats 返回租户的指标，租户不存在时返回 false
+func (e *FairShareExecutor) TenantStats(key any) (TenantStats, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	t, ok := e.tenants[key]
+	if !ok {
+		return TenantStats{}, false
+	}
+	return TenantStats{
+		TenantConfig: t.cfg,
+		Running:      t.running,
+		Queued:       len(t.queue),
+		Submitted:    t.submitted,
+		Completed:    t.completed,
+		Panicked:     t.panicked,
+		Rejected:     t.rejected,
+		QueueWait:    t.queueWait.snapshot(),
+	}, true
+}
+
+// Tenants 返回所有已知租户的 key
+func (e *FairShareExecutor) Tenants() []any {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	keys := make([]any, 0, len(e.tenants))
+	for k := range e.tenants {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Stats 返回运行指标
+func (e *FairShareExecutor) Stats() Stats {
+	s := e.snapshot()
+	e.mu.RLock()
+	s.Workers = e.alive
+	s.QueuedTasks = e.queued
+	e.mu.RUnlock()
+	return s
+}
+
+// Shutdown 停止接受新任务，队列中的任务按公平调度执行完后 worker 退出
+func (e *FairShareExecutor) Shutdown() {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	e.mu.Unlock()
+	e.cond.Broadcast()
+}
+
+// ShutdownNow 停止接受新任务，返回所有租户尚未开始的任务
+func (e *FairShareExecutor) ShutdownNow() []Runnable {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	var jobs []job
+	for _, t := range e.ring {
+		jobs = append(jobs, t.queue...)
+		t.rejected += uint64(len(t.queue))
+		t.queue = nil
+		t.deficit = 0
+		t.inRing = false
+	}
+	e.ring = nil
+	e.cursor = 0
+	e.queued = 0
+	e.mu.Unlock()
+	e.cond.Broadcast()
+
+	e.rejected.Add(uint64(len(jobs)))
+	pending := make([]Runnable, 0, len(jobs))
+	for _, j := range jobs {
+		NotifyRejected(j.ctx, ErrShutdown)
+		pending = append(pending, j.task)
+	}
+	return pending
+}
+
+func (e *FairShareExecutor) tenantLocked(key any) *tenant {
+	t, ok := e.tenants[key]
+	if !ok {
+		t = &tenant{key: key, cfg: e.defaults}
+		e.tenants[key] = t
+	}
+	return t
+}
+
+// nextLocked 按加权差额轮询选出下一个任务，所有有积压的租户都达到并发上限时返回 false
+// 租户的一轮从 deficit 补满 Weight 开始，每启动一个任务减 1，用完或队列清空后轮到下一个租户；
+// 因并发上限被跳过的租户保留剩余额度，下次轮到时继续
+func (e *FairShareExecutor) nextLocked() (*tenant, job, bool) {
+	for skipped := 0; skipped < len(e.ring); {
+		if e.cursor >= len(e.ring) {
+			e.cursor = 0
+		}
+		t := e.ring[e.cursor]
+		if t.cfg.MaxConcurrency > 0 && t.running >= t.cfg.MaxConcurrency {
+			e.cursor++
+			skipped++
+			continue
+		}
+		if t.deficit == 0 {
+			t.deficit = t.cfg.Weight
+		}
+		j := t.queue[0]
+		t.queue[0] = job{}
+		t.queue = t.queue[1:]
+		t.deficit--
+		t.running++
+		e.queued--
+		if len(t.queue) == 0 {
+			// 队列清空时放弃剩余额度并移出调度环，下次提交时重新加入
+			t.queue = nil
+			t.deficit = 0
+			t.inRing = false
+			e.ring = append(e.ring[:e.cursor], e.ring[e.cursor+1:]...)
+		} else if t.deficit == 0 {
+			e.cursor++
+		}
+		return t, j, true
+	}
+	return nil, job{}, false
+}
+
+func (e *FairShareExecutor) work() {
+	e.mu.Lock()
+	for {
+		if e.retireLocked() {
+			e.mu.Unlock()
+			return
+		}
+		t, j, ok := e.nextLocked()
+		if !ok {
+			if e.shutdown && e.queued == 0 {
+				e.alive--
+				if e.alive == 0 {
+					e.terminate()
+				}
+				e.mu.Unlock()
+				return
+			}
+			e.cond.Wait()
+			continue
+		}
+		e.mu.Unlock()
+
+		rejected, panicked := false, false
+		if err := j.ctx.Err(); err != nil {
+			rejected = true
+			e.rejected.Add(1)
+			NotifyRejected(j.ctx, err)
+		} else {
+			t.queueWait.observe(time.Since(j.enqueued))
+			panicked = e.run(j.ctx, j.task, j.enqueued)
+		}
+
+		e.mu.Lock()
+		t.running--
+		switch {
+		case rejected:
+			t.rejected++
+		case panicked:
+			t.panicked++
+		default:
+			t.completed++
+		}
+		if t.cfg.MaxConcurrency > 0 && len(t.queue) > 0 {
+			// 租户从并发上限回落，可能有 worker 因它而等待
+			e.cond.Signal()
+		} else if e.shutdown && e.queued == 0 {
+			// 队列已清空，唤醒因并发上限而等待的 worker 退出
+			e.cond.Broadcast()
+		}
+	}
+}
+
+// tenantView 绑定到单个租户的执行器
+type tenantView struct {
+	e   *FairShareExecutor
+	key any
+}
+
+func (v tenantView) Submit(task Runnable) {
+	_ = v.e.SubmitTenant(context.Background(), v.key, task)
+}
+
+func (v tenantView) SubmitCtx(ctx context.Context, task Runnable) error {
+	return v.e.SubmitTenant(ctx, v.key, task)
+}
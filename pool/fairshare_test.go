@@ -0,0 +1,174 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFairShareExecutor_WeightedRoundRobin(t *testing.T) {
+	e := NewFairShareExecutor(1, TenantConfig{})
+	defer e.Shutdown()
+	e.SetTenant("a", TenantConfig{Weight: 2})
+
+	// 先占住唯一的 worker，让两个租户的任务都排队
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-gate })
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b"} {
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			_ = e.SubmitTenant(context.Background(), key, func() {
+				defer wg.Done()
+				mu.Lock()
+				order = append(order, key)
+				mu.Unlock()
+			})
+		}
+	}
+	close(gate)
+	wg.Wait()
+
+	want := []string{"a", "a", "b", "a", "a", "b", "a", "a", "b", "b", "b", "b"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestFairShareExecutor_MaxConcurrency(t *testing.T) {
+	e := NewFairShareExecutor(4, TenantConfig{})
+	defer e.Shutdown()
+	e.SetTenant("noisy", TenantConfig{MaxConcurrency: 1})
+
+	var running, maxRunning atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		_ = e.SubmitTenant(context.Background(), "noisy", func() {
+			defer wg.Done()
+			cur := running.Add(1)
+			if cur > maxRunning.Load() {
+				maxRunning.Store(cur)
+			}
+			time.Sleep(2 * time.Millisecond)
+			running.Add(-1)
+		})
+	}
+
+	// 受限租户占满积压时，其他租户仍能使用空闲的 worker
+	quiet := make(chan struct{})
+	_ = e.SubmitTenant(context.Background(), "quiet", func() { close(quiet) })
+	select {
+	case <-quiet:
+	case <-time.After(time.Second):
+		t.Fatal("Quiet tenant starved by capped tenant")
+	}
+	wg.Wait()
+
+	if maxRunning.Load() != 1 {
+		t.Errorf("Expected at most 1 concurrent task, got %d", maxRunning.Load())
+	}
+	// 任务返回后 worker 才更新租户计数
+	waitFor(t, func() bool {
+		s, _ := e.TenantStats("noisy")
+		return s.Completed == 10
+	}, "Tenant bookkeeping did not settle")
+	s, ok := e.TenantStats("noisy")
+	if !ok || s.Completed != 10 || s.Submitted != 10 || s.Running != 0 || s.QueueWait.Count != 10 {
+		t.Errorf("Unexpected tenant stats: %+v", s)
+	}
+}
+
+func TestFairShareExecutor_MaxQueued(t *testing.T) {
+	e := NewFairShareExecutor(1, TenantConfig{MaxQueued: 1})
+	defer e.ShutdownNow()
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	_ = e.SubmitCtx(WithTenant(context.Background(), "t"), func() { close(started); <-gate })
+	<-started
+	defer close(gate)
+
+	if err := e.SubmitTenant(context.Background(), "t", func() {}); err != nil {
+		t.Fatalf("Expected queued, got %v", err)
+	}
+	if err := e.SubmitTenant(context.Background(), "t", func() {}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+	// 其他租户不受影响
+	if err := e.SubmitTenant(context.Background(), "other", func() {}); err != nil {
+		t.Fatalf("Expected queued, got %v", err)
+	}
+
+	s, _ := e.TenantStats("t")
+	if s.Running != 1 || s.Queued != 1 || s.Rejected != 1 {
+		t.Errorf("Unexpected tenant stats: %+v", s)
+	}
+	if len(e.Tenants()) != 2 {
+		t.Errorf("Expected 2 tenants, got %v", e.Tenants())
+	}
+}
+
+func TestFairShareExecutor_ShutdownNow(t *testing.T) {
+	e := NewFairShareExecutor(1, TenantConfig{})
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-gate })
+	<-started
+
+	var notified atomic.Int32
+	ctx := WithRejectHandler(context.Background(), func(err error) {
+		if errors.Is(err, ErrShutdown) {
+			notified.Add(1)
+		}
+	})
+	for i := 0; i < 3; i++ {
+		_ = e.SubmitTenant(ctx, i, func() { t.Error("Pending task must not run") })
+	}
+
+	if pending := e.ShutdownNow(); len(pending) != 3 {
+		t.Fatalf("Expected 3 pending tasks, got %d", len(pending))
+	}
+	if notified.Load() != 3 {
+		t.Errorf("Expected 3 rejection notifications, got %d", notified.Load())
+	}
+	close(gate)
+
+	ctx2, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := e.AwaitTermination(ctx2); err != nil {
+		t.Fatal(err)
+	}
+	if s := e.Stats(); s.Completed != 1 || s.Rejected != 3 || s.QueuedTasks != 0 {
+		t.Errorf("Unexpected stats: %+v", s)
+	}
+}
+
+func TestFairShareExecutor_ShutdownWithCappedTenant(t *testing.T) {
+	e := NewFairShareExecutor(4, TenantConfig{MaxConcurrency: 1})
+	for i := 0; i < 5; i++ {
+		e.Submit(func() { time.Sleep(time.Millisecond) })
+	}
+	e.Shutdown()
+
+	// 因并发上限而等待的 worker 必须在队列清空后被唤醒退出
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := e.AwaitTermination(ctx); err != nil {
+		t.Fatalf("AwaitTermination: %v, stats %+v", err, e.Stats())
+	}
+	if s := e.Stats(); s.Workers != 0 || s.Completed != 5 {
+		t.Errorf("Unexpected stats %+v", s)
+	}
+}
@@ -0,0 +1,331 @@
+package pool
+
+import (
+	"context"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerTask 可以拿到当前 worker 的任务，用于在任务内部继续 Fork 子任务
+type WorkerTask func(w *ForkJoinWorker)
+
+// ForkJoinPool 工作窃取执行器
+// 每个 worker 拥有一个双端队列：自己从队尾 (LIFO) 取任务，空闲时从其他 worker 的队头 (FIFO) 窃取。
+// 在 worker 内部等待子任务时应使用 HelpUntil，让等待中的 worker 继续执行队列中的任务，而不是阻塞占用 worker。
+// 任务内的托管阻塞（如嵌套 Join）只会报告给 SetBlockingDebug，不会补偿 worker：
+// 阻塞的 worker 本地队列中的子任务仍可被窃取，但 worker 全部阻塞时池会饥饿
+type ForkJoinPool struct {
+	lifecycle
+	metrics
+	workers  []*ForkJoinWorker
+	external deque // 外部提交的任务
+	blockCounter
+
+	wake     chan struct{} // 有新任务时关闭并替换，受 lifecycle.mu 保护
+	sleepers atomic.Int32
+	alive    atomic.Int32
+}
+
+// ForkJoinWorker ForkJoinPool 中的一个 worker
+type ForkJoinWorker struct {
+	pool  *ForkJoinPool
+	id    int
+	local deque
+}
+
+type fjTask struct {
+	ctx      context.Context // 外部提交时的 ctx，用于拒绝通知；Fork 的子任务为 nil
+	run      WorkerTask
+	enqueued time.Time
+}
+
+// deque 带锁的双端队列，owner 操作队尾，窃取者操作队头
+type deque struct {
+	mu    sync.Mutex
+	items []fjTask
+}
+
+func (d *deque) pushBottom(t fjTask) {
+	d.mu.Lock()
+	d.items = append(d.items, t)
+	d.mu.Unlock()
+}
+
+func (d *deque) popBottom() (fjTask, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(d.items)
+	if n == 0 {
+		return fjTask{}, false
+	}
+	t := d.items[n-1]
+	d.items[n-1] = fjTask{}
+	d.items = d.items[:n-1]
+	return t, true
+}
+
+func (d *deque) popTop() (fjTask, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.items) == 0 {
+		return fjTask{}, false
+	}
+	t := d.items[0]
+	d.items[0] = fjTask{}
+	d.items = d.items[1:]
+	return t, true
+}
+
+func (d *deque) drain() []fjTask {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	items := d.items
+	d.items = nil
+	return items
+}
+
+func (d *deque) len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.items)
+}
+
+// NewForkJoinPool 创建并行度为 parallelism 的工作窃取执行器，<= 0 时使用 runtime.GOMAXPROCS(0)
+func NewForkJoinPool(parallelism int) *ForkJoinPool {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	p := &ForkJoinPool{
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "forkjoin"},
+		workers:   make([]*ForkJoinWorker, parallelism),
+		wake:      make(chan struct{}),
+	}
+	p.alive.Store(int32(parallelism))
+	for i := range p.workers {
+		p.workers[i] = &ForkJoinWorker{pool: p, id: i}
+	}
+	for _, w := range p.workers {
+		go w.loop()
+	}
+	return p
+}
+
+// Submit 从外部提交普通任务
+func (p *ForkJoinPool) Submit(task Runnable) {
+	_ = p.SubmitWorkerTask(func(*ForkJoinWorker) { task() })
+}
+
+// SubmitCtx 从外部提交普通任务，开始前 ctx 已结束的任务会被丢弃
+func (p *ForkJoinPool) SubmitCtx(ctx context.Context, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return p.submit(ctx, func(*ForkJoinWorker) {
+		if err := ctx.Err(); err != nil {
+			p.rejected.Add(1)
+			NotifyRejected(ctx, err)
+			return
+		}
+		task()
+	})
+}
+
+// SubmitWorkerTask 从外部提交可以 Fork 子任务的根任务
+func (p *ForkJoinPool) SubmitWorkerTask(task WorkerTask) error {
+	return p.submit(context.Background(), task)
+}
+
+func (p *ForkJoinPool) submit(ctx context.Context, task WorkerTask) error {
+	p.mu.RLock()
+	if p.shutdown {
+		p.mu.RUnlock()
+		p.rejected.Add(1)
+		return ErrShutdown
+	}
+	p.external.pushBottom(fjTask{ctx: ctx, run: task, enqueued: time.Now()})
+	p.mu.RUnlock()
+	p.submitted.Add(1)
+	p.signalWork()
+	return nil
+}
+
+// Shutdown 停止接受外部任务，已提交的任务及其派生的子任务执行完后 worker 退出
+func (p *ForkJoinPool) Shutdown() {
+	p.mu.Lock()
+	if p.beginShutdownLocked() {
+		close(p.wake)
+		p.wake = make(chan struct{})
+	}
+	p.mu.Unlock()
+}
+
+// ShutdownNow 停止接受外部任务并清空所有队列，返回尚未开始的任务
+// 被清空的子任务对应的 Join 将无法完成，仅应在放弃整个计算时使用
+func (p *ForkJoinPool) ShutdownNow() []Runnable {
+	p.Shutdown()
+	var tasks []fjTask
+	tasks = append(tasks, p.external.drain()...)
+	for _, w := range p.workers {
+		tasks = append(tasks, w.local.drain()...)
+	}
+	p.rejected.Add(uint64(len(tasks)))
+	pending := make([]Runnable, 0, len(tasks))
+	for _, t := range tasks {
+		if t.ctx != nil {
+			NotifyRejected(t.ctx, ErrShutdown)
+		}
+		run := t.run
+		pending = append(pending, func() { run(nil) })
+	}
+	return pending
+}
+
+// Stats 返回运行指标
+func (p *ForkJoinPool) Stats() Stats {
+	s := p.snapshot()
+	s.Workers = int(p.alive.Load())
+	s.QueuedTasks = p.external.len()
+	for _, w := range p.workers {
+		s.QueuedTasks += w.local.len()
+	}
+	return s
+}
+
+// Parallelism 返回 worker 数量
+func (p *ForkJoinPool) Parallelism() int {
+	return len(p.workers)
+}
+
+// beginBlocking 只计数用于调试报告，不补偿 worker，需要在等待期间继续执行任务时使用 HelpUntil
+func (p *ForkJoinPool) beginBlocking() (int, bool) {
+	return p.enter(false)
+}
+
+func (p *ForkJoinPool) endBlocking(compensated bool) {
+	p.exit(compensated)
+}
+
+// Fork 将子任务推入当前 worker 的本地队列，空闲的 worker 会来窃取
+func (w *ForkJoinWorker) Fork(task WorkerTask) {
+	w.local.pushBottom(fjTask{run: task, enqueued: time.Now()})
+	w.pool.submitted.Add(1)
+	w.pool.signalWork()
+}
+
+// Pool 返回 worker 所属的池
+func (w *ForkJoinWorker) Pool() *ForkJoinPool {
+	return w.pool
+}
+
+// HelpUntil 在 done 关闭前持续执行本地、窃取或外部队列中的任务，没有任务时才真正等待
+// 必须在 w 所在的 goroutine（即 w 正在执行的任务内部）调用
+func (w *ForkJoinWorker) HelpUntil(done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if t, ok := w.next(); ok {
+			w.run(t)
+			continue
+		}
+		w.pool.waitWork(done)
+	}
+}
+
+func (w *ForkJoinWorker) loop() {
+	p := w.pool
+	for {
+		if t, ok := w.next(); ok {
+			w.run(t)
+			continue
+		}
+		if p.IsShutdown() && !p.hasWork() {
+			if p.alive.Add(-1) == 0 {
+				p.terminate()
+			}
+			// 唤醒其他仍在等待的 worker 检查退出条件
+			p.signalWork()
+			return
+		}
+		p.waitWork(nil)
+	}
+}
+
+func (w *ForkJoinWorker) run(t fjTask) {
+	ctx := t.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	w.pool.metrics.run(ctx, func() { t.run(w) }, t.enqueued)
+}
+
+// next 依次尝试：本地队尾 -> 从其他 worker 窃取 -> 外部队列
+func (w *ForkJoinWorker) next() (fjTask, bool) {
+	if t, ok := w.local.popBottom(); ok {
+		return t, true
+	}
+	p := w.pool
+	n := len(p.workers)
+	start := rand.IntN(n)
+	for i := 0; i < n; i++ {
+		victim := p.workers[(start+i)%n]
+		if victim == w {
+			continue
+		}
+		if t, ok := victim.local.popTop(); ok {
+			return t, true
+		}
+	}
+	return p.external.popTop()
+}
+
+func (p *ForkJoinPool) hasWork() bool {
+	if p.external.len() > 0 {
+		return true
+	}
+	for _, w := range p.workers {
+		if w.local.len() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// signalWork 有等待者时广播唤醒
+func (p *ForkJoinPool) signalWork() {
+	if p.sleepers.Load() == 0 {
+		return
+	}
+	p.mu.Lock()
+	close(p.wake)
+	p.wake = make(chan struct{})
+	p.mu.Unlock()
+}
+
+// waitWork 等待新任务或 done 关闭
+// 先登记为等待者再检查队列，保证与 signalWork 之间不会丢失唤醒
+func (p *ForkJoinPool) waitWork(done <-chan struct{}) {
+	p.mu.RLock()
+	wake := p.wake
+	shutdown := p.shutdown
+	p.mu.RUnlock()
+
+	p.sleepers.Add(1)
+	defer p.sleepers.Add(-1)
+	if p.hasWork() || (shutdown && done == nil) {
+		return
+	}
+	select {
+	case <-wake:
+	case <-done:
+	}
+}
@@ -0,0 +1,127 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForkJoinPool_WorkStealing(t *testing.T) {
+	p := NewForkJoinPool(4)
+	defer p.Shutdown()
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var wg sync.WaitGroup
+	wg.Add(16)
+
+	// 根任务在一个 worker 上 Fork 出所有子任务，其他 worker 只能通过窃取拿到
+	_ = p.SubmitWorkerTask(func(w *ForkJoinWorker) {
+		for i := 0; i < 16; i++ {
+			w.Fork(func(w *ForkJoinWorker) {
+				defer wg.Done()
+				mu.Lock()
+				seen[w.id] = true
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+			})
+		}
+	})
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) < 2 {
+		t.Errorf("Forked tasks should be stolen by other workers, ran on %d worker(s)", len(seen))
+	}
+}
+
+func TestForkJoinPool_HelpUntil(t *testing.T) {
+	p := NewForkJoinPool(1)
+	defer p.Shutdown()
+
+	result := make(chan int, 1)
+	_ = p.SubmitWorkerTask(func(w *ForkJoinWorker) {
+		done := make(chan struct{})
+		var v int
+		w.Fork(func(*ForkJoinWorker) {
+			v = 42
+			close(done)
+		})
+		// 唯一的 worker 在等待时自己执行子任务
+		w.HelpUntil(done)
+		result <- v
+	})
+
+	select {
+	case v := <-result:
+		if v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("HelpUntil deadlocked")
+	}
+}
+
+func TestForkJoinPool_Shutdown(t *testing.T) {
+	p := NewForkJoinPool(2)
+	var ran int32
+	for i := 0; i < 10; i++ {
+		p.Submit(func() {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&ran, 1)
+		})
+	}
+	p.Shutdown()
+	if err := p.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&ran) != 10 {
+		t.Errorf("Expected 10 tasks to run, got %d", ran)
+	}
+	if s := p.Stats(); s.Workers != 0 || s.Completed != 10 {
+		t.Errorf("Unexpected stats after termination: %+v", s)
+	}
+}
+
+func TestForkJoinPool_ShutdownNowNotifiesRejected(t *testing.T) {
+	p := NewForkJoinPool(1)
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	p.Submit(func() { close(started); <-gate })
+	<-started
+
+	var notified atomic.Int32
+	ctx := WithRejectHandler(context.Background(), func(err error) {
+		if errors.Is(err, ErrShutdown) {
+			notified.Add(1)
+		}
+	})
+	for i := 0; i < 3; i++ {
+		if err := p.SubmitCtx(ctx, func() { t.Error("Drained task must not run") }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pending := p.ShutdownNow(); len(pending) != 3 {
+		t.Fatalf("Expected 3 pending tasks, got %d", len(pending))
+	}
+	if notified.Load() != 3 {
+		t.Errorf("Expected 3 ErrShutdown notifications, got %d", notified.Load())
+	}
+	close(gate)
+}
+
+func TestForkJoinPool_SubmitCtxNilContext(t *testing.T) {
+	p := NewForkJoinPool(1)
+	defer p.Shutdown()
+
+	done := make(chan struct{})
+	if err := p.SubmitCtx(nil, func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	waitClosed(t, done, "Task submitted with nil ctx did not run")
+}
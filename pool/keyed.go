@@ -0,0 +1,164 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyedExecutor 按 key 串行的执行器：同一 key 的任务严格按提交顺序依次执行，不同 key 之间在底层执行器上并行
+// 每个有积压任务的 key 占用底层执行器的一个任务位，依次执行完队列后释放；空闲的 key 不占用任何 goroutine 或内存
+// key 必须是可比较的类型，与 map 的 key 要求相同
+type KeyedExecutor struct {
+	metrics
+	base Executor
+
+	mu     sync.Mutex
+	queues map[any]*keyQueue
+}
+
+type keyQueue struct {
+	jobs []job
+}
+
+// NewKeyedExecutor 创建基于 base 的按 key 串行执行器，base 为 nil 时使用当前的全局执行器
+func NewKeyedExecutor(base Executor) *KeyedExecutor {
+	if base == nil {
+		base = Global()
+	}
+	return &KeyedExecutor{
+		metrics: metrics{name: "keyed"},
+		base:    base,
+		queues:  make(map[any]*keyQueue),
+	}
+}
+
+// SubmitKey 提交 key 对应的任务
+func (e *KeyedExecutor) SubmitKey(key any, task Runnable) {
+	_ = e.SubmitKeyCtx(context.Background(), key, task)
+}
+
+// SubmitKeyCtx 提交 key 对应的任务，轮到它时 ctx 已结束则跳过（同一 key 后续任务照常执行）
+// 底层执行器拒绝时返回错误，该 key 当前排队的任务都会以同一错误被通知
+func (e *KeyedExecutor) SubmitKeyCtx(ctx context.Context, key any, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	j := job{ctx: ctx, task: task, enqueued: time.Now()}
+
+	e.mu.Lock()
+	if q, ok := e.queues[key]; ok {
+		// 已有任务在执行或排队，追加到队尾即可，由当前的 drain 负责执行
+		q.jobs = append(q.jobs, j)
+		e.mu.Unlock()
+		e.submitted.Add(1)
+		return nil
+	}
+	q := &keyQueue{jobs: []job{j}}
+	e.queues[key] = q
+	e.mu.Unlock()
+	e.submitted.Add(1)
+
+	drain := func() { e.drain(key, q) }
+	var err error
+	if ce, ok := e.base.(ContextExecutor); ok {
+		// 底层执行器按 DiscardPolicy 等策略静默丢弃 drain 时同样需要通知排队的任务，否则该 key 会永远阻塞
+		rctx := WithRejectHandler(context.Background(), func(err error) { e.fail(key, q, err) })
+		err = ce.SubmitCtx(rctx, drain)
+	} else {
+		e.base.Submit(drain)
+	}
+	if err != nil {
+		e.fail(key, q, err)
+	}
+	return err
+}
+
+// ForKey 返回绑定到 key 的 Executor 视图，可直接传给 future 的 *WithExecutor 系列函数，
+// 使整条链的异步阶段都在同一 key 上串行执行
+func (e *KeyedExecutor) ForKey(key any) ContextExecutor {
+	return keyedView{e: e, key: key}
+}
+
+// ActiveKeys 返回当前有任务在执行或排队的 key 数量
+func (e *KeyedExecutor) ActiveKeys() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.queues)
+}
+
+// Stats 返回运行指标，Workers 为活跃 key 数，QueuedTasks 为所有 key 排队中的任务数
+func (e *KeyedExecutor) Stats() Stats {
+	s := e.snapshot()
+	e.mu.Lock()
+	s.Workers = len(e.queues)
+	for _, q := range e.queues {
+		s.QueuedTasks += len(q.jobs)
+	}
+	e.mu.Unlock()
+	// 正在执行的任务仍留在队首
+	s.QueuedTasks = max(s.QueuedTasks-s.ActiveWorkers, 0)
+	return s
+}
+
+// drain 在底层执行器上依次执行 key 的队列，队列为空时移除 key
+// 任务执行完后才从队列中移除，因此执行期间到来的新任务会被追加而不是开启第二个 drain
+func (e *KeyedExecutor) drain(key any, q *keyQueue) {
+	for {
+		e.mu.Lock()
+		if len(q.jobs) == 0 {
+			// drain 被拒绝后 fail 已通知并清空了队列，之后仍被执行（如运行 ShutdownNow 返回的任务）时直接返回
+			e.mu.Unlock()
+			return
+		}
+		j := q.jobs[0]
+		e.mu.Unlock()
+
+		if err := j.ctx.Err(); err != nil {
+			e.rejected.Add(1)
+			NotifyRejected(j.ctx, err)
+		} else {
+			e.run(j.ctx, j.task, j.enqueued)
+		}
+
+		e.mu.Lock()
+		q.jobs[0] = job{}
+		q.jobs = q.jobs[1:]
+		if len(q.jobs) == 0 {
+			delete(e.queues, key)
+			e.mu.Unlock()
+			return
+		}
+		e.mu.Unlock()
+	}
+}
+
+// fail 底层执行器拒绝 drain 时通知该 key 所有排队的任务
+func (e *KeyedExecutor) fail(key any, q *keyQueue, err error) {
+	e.mu.Lock()
+	jobs := q.jobs
+	q.jobs = nil
+	if e.queues[key] == q {
+		delete(e.queues, key)
+	}
+	e.mu.Unlock()
+
+	e.rejected.Add(uint64(len(jobs)))
+	for _, j := range jobs {
+		NotifyRejected(j.ctx, err)
+	}
+}
+
+// keyedView 绑定到单个 key 的执行器
+type keyedView struct {
+	e   *KeyedExecutor
+	key any
+}
+
+func (v keyedView) Submit(task Runnable) {
+	v.e.SubmitKey(v.key, task)
+}
+
+func (v keyedView) SubmitCtx(ctx context.Context, task Runnable) error {
+	return v.e.SubmitKeyCtx(ctx, v.key, task)
+}
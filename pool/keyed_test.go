@@ -0,0 +1,165 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedExecutor_PerKeyFIFO(t *testing.T) {
+	e := NewKeyedExecutor(NewBlockingExecutor(8))
+
+	const keys, perKey = 10, 200
+	var mu sync.Mutex
+	got := make(map[int][]int)
+	var wg sync.WaitGroup
+	wg.Add(keys * perKey)
+	for i := 0; i < perKey; i++ {
+		for k := 0; k < keys; k++ {
+			k, i := k, i
+			e.SubmitKey(k, func() {
+				defer wg.Done()
+				mu.Lock()
+				got[k] = append(got[k], i)
+				mu.Unlock()
+			})
+		}
+	}
+	wg.Wait()
+
+	for k := 0; k < keys; k++ {
+		for i, v := range got[k] {
+			if v != i {
+				t.Fatalf("Key %d executed out of order: %v", k, got[k][:i+1])
+			}
+		}
+	}
+	waitFor(t, func() bool { return e.ActiveKeys() == 0 }, "Idle keys should be released")
+}
+
+func TestKeyedExecutor_KeysRunInParallel(t *testing.T) {
+	e := NewKeyedExecutor(NewBlockingExecutor(4))
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for k := 0; k < 4; k++ {
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			e.SubmitKey(fmt.Sprint("acct-", k), func() {
+				defer wg.Done()
+				cur := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&maxRunning)
+					if cur <= old || atomic.CompareAndSwapInt32(&maxRunning, old, cur) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			})
+		}
+	}
+	wg.Wait()
+
+	if maxRunning < 2 {
+		t.Errorf("Different keys should run in parallel, max running: %d", maxRunning)
+	}
+	if maxRunning > 4 {
+		t.Errorf("At most one task per key should run, max running: %d", maxRunning)
+	}
+}
+
+func TestKeyedExecutor_PanicAndCancelledTasksDoNotStallKey(t *testing.T) {
+	e := NewKeyedExecutor(NewBlockingExecutor(1))
+	e.SetPanicHandler(func(PanicInfo) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var skipped atomic.Bool
+	rctx := WithRejectHandler(ctx, func(error) { skipped.Store(true) })
+
+	done := make(chan struct{})
+	e.SubmitKey("k", func() { panic("boom") })
+	_ = e.SubmitKeyCtx(rctx, "k", func() { t.Error("Cancelled task should be skipped") })
+	e.SubmitKey("k", func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Key stalled after panic")
+	}
+	if !skipped.Load() {
+		t.Error("Cancelled task should be reported as rejected")
+	}
+	if s := e.Stats(); s.Panicked != 1 {
+		t.Errorf("Expected 1 panic, got %d", s.Panicked)
+	}
+}
+
+func TestKeyedExecutor_BaseDiscardFailsQueuedTasks(t *testing.T) {
+	base := NewWorkerPoolWithConfig(WorkerPoolConfig{MinWorkers: 1, Rejection: DiscardPolicy})
+	defer base.Shutdown()
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	var once sync.Once
+	blocker := func() {
+		once.Do(func() { close(started) })
+		<-release
+	}
+	// worker 尚未就绪时任务会被直接丢弃，重试直到占住唯一的 worker
+	for ready := false; !ready; {
+		base.Submit(blocker)
+		select {
+		case <-started:
+			ready = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	e := NewKeyedExecutor(base)
+	errs := make(chan error, 1)
+	_ = e.SubmitKeyCtx(WithRejectHandler(context.Background(), func(err error) { errs <- err }), "k", func() {})
+
+	select {
+	case err := <-errs:
+		if err != ErrRejected {
+			t.Errorf("Expected ErrRejected, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Discarded drain should notify queued tasks")
+	}
+	if e.ActiveKeys() != 0 {
+		t.Error("Key should be released after its drain was discarded")
+	}
+}
+
+func TestKeyedExecutor_RunningDrainedTaskAfterShutdownNow(t *testing.T) {
+	base := NewWorkerPool(1, 4)
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	base.Submit(func() { close(started); <-gate })
+	<-started
+
+	e := NewKeyedExecutor(base)
+	var rejected error
+	ctx := WithRejectHandler(context.Background(), func(err error) { rejected = err })
+	_ = e.SubmitKeyCtx(ctx, "k", func() { t.Error("Rejected task must not run") })
+
+	pending := base.ShutdownNow()
+	close(gate)
+	if !errors.Is(rejected, ErrShutdown) {
+		t.Fatalf("Expected ErrShutdown, got %v", rejected)
+	}
+	// 调用方仍可执行 ShutdownNow 返回的任务，drain 不能因队列已被清空而 panic
+	for _, task := range pending {
+		task()
+	}
+	if e.ActiveKeys() != 0 {
+		t.Error("Key should be released")
+	}
+}
@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrShutdown 执行器已关闭，不再接受新任务
+var ErrShutdown = errors.New("pool: executor has been shut down")
+
+// Lifecycle 可关闭的执行器
+type Lifecycle interface {
+	// Shutdown 停止接受新任务，已提交的任务（包括排队中的）继续执行，不阻塞调用方
+	Shutdown()
+	// ShutdownNow 停止接受新任务并清空队列，返回尚未开始的任务
+	// 这些任务的拒绝回调会以 ErrShutdown 被通知；正在运行的任务无法被打断
+	ShutdownNow() []Runnable
+	// AwaitTermination 阻塞直到关闭后所有任务执行完毕，或 ctx 结束
+	AwaitTermination(ctx context.Context) error
+	IsShutdown() bool
+	// IsTerminated 关闭后所有任务均已执行完毕
+	IsTerminated() bool
+}
+
+// ManagedExecutor 同时支持错误报告与生命周期管理的执行器
+type ManagedExecutor interface {
+	ExecutorService
+	Lifecycle
+}
+
+// lifecycle 内置执行器共用的关闭状态
+type lifecycle struct {
+	mu         sync.RWMutex
+	shutdown   bool
+	quit       chan struct{} // Shutdown 时关闭
+	terminated chan struct{} // 所有任务结束后关闭
+	termOnce   sync.Once
+}
+
+func newLifecycle() lifecycle {
+	return lifecycle{
+		quit:       make(chan struct{}),
+		terminated: make(chan struct{}),
+	}
+}
+
+// beginShutdownLocked 标记关闭，返回 false 表示已经关闭过，调用方需持有 mu
+func (l *lifecycle) beginShutdownLocked() bool {
+	if l.shutdown {
+		return false
+	}
+	l.shutdown = true
+	close(l.quit)
+	return true
+}
+
+func (l *lifecycle) terminate() {
+	l.termOnce.Do(func() { close(l.terminated) })
+}
+
+func (l *lifecycle) IsShutdown() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.shutdown
+}
+
+func (l *lifecycle) IsTerminated() bool {
+	select {
+	case <-l.terminated:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *lifecycle) AwaitTermination(ctx context.Context) error {
+	select {
+	case <-l.terminated:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,155 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_ShutdownDrainsQueue(t *testing.T) {
+	p := NewWorkerPool(2, 10)
+	var done int32
+	for i := 0; i < 10; i++ {
+		p.Submit(func() {
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&done, 1)
+		})
+	}
+	p.Shutdown()
+
+	if err := p.SubmitCtx(context.Background(), func() {}); err != ErrShutdown {
+		t.Fatalf("Expected ErrShutdown, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := p.AwaitTermination(ctx); err != nil {
+		t.Fatalf("AwaitTermination failed: %v", err)
+	}
+	if atomic.LoadInt32(&done) != 10 {
+		t.Errorf("Expected all 10 queued tasks to run, got %d", done)
+	}
+	if !p.IsShutdown() || !p.IsTerminated() {
+		t.Error("Pool should be shut down and terminated")
+	}
+	if p.Workers() != 0 {
+		t.Errorf("All workers should exit, got %d", p.Workers())
+	}
+}
+
+func TestWorkerPool_ShutdownNow(t *testing.T) {
+	p := NewWorkerPool(1, 10)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	p.Submit(func() { close(started); <-release })
+	<-started
+
+	var rejected int32
+	ctx := WithRejectHandler(context.Background(), func(err error) {
+		if err == ErrShutdown {
+			atomic.AddInt32(&rejected, 1)
+		}
+	})
+	for i := 0; i < 3; i++ {
+		_ = p.SubmitCtx(ctx, func() { t.Error("Pending task should not run") })
+	}
+
+	pending := p.ShutdownNow()
+	if len(pending) != 3 {
+		t.Errorf("Expected 3 pending tasks, got %d", len(pending))
+	}
+	if atomic.LoadInt32(&rejected) != 3 {
+		t.Errorf("Expected 3 reject notifications, got %d", rejected)
+	}
+
+	ctxWait, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.AwaitTermination(ctxWait); err != context.DeadlineExceeded {
+		t.Fatalf("Running task should delay termination, got %v", err)
+	}
+	close(release)
+	if err := p.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkerPool_ShutdownUnblocksSubmitters(t *testing.T) {
+	p := NewWorkerPool(1, 0)
+	release := make(chan struct{})
+	defer close(release)
+	p.Submit(func() { <-release })
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- p.SubmitCtx(context.Background(), func() {}) }()
+	time.Sleep(10 * time.Millisecond)
+	p.Shutdown()
+
+	select {
+	case err := <-errCh:
+		if err != ErrShutdown {
+			t.Fatalf("Expected ErrShutdown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Blocked submitter was not released by Shutdown")
+	}
+}
+
+func TestWorkerPool_ElasticTermination(t *testing.T) {
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{MaxWorkers: 2, KeepAlive: 5 * time.Millisecond})
+	p.Submit(func() {})
+	time.Sleep(20 * time.Millisecond) // 所有 worker 空闲退出
+	p.Shutdown()
+	if err := p.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlockingExecutor_Lifecycle(t *testing.T) {
+	exec := NewBlockingExecutorWithPolicy(2, BlockPolicy)
+	release := make(chan struct{})
+	exec.Submit(func() { <-release })
+	exec.Shutdown()
+
+	if err := exec.SubmitCtx(context.Background(), func() {}); err != ErrShutdown {
+		t.Fatalf("Expected ErrShutdown, got %v", err)
+	}
+	if exec.IsTerminated() {
+		t.Fatal("Executor should not terminate while a task is running")
+	}
+	close(release)
+	if err := exec.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimitedExecutor_Lifecycle(t *testing.T) {
+	exec := NewRateLimitedExecutor(NewBlockingExecutor(2), 50, 1)
+	var ran int32
+	for i := 0; i < 3; i++ {
+		exec.Submit(func() { atomic.AddInt32(&ran, 1) })
+	}
+	exec.Shutdown()
+	if err := exec.SubmitCtx(context.Background(), func() {}); err != ErrShutdown {
+		t.Fatalf("Expected ErrShutdown, got %v", err)
+	}
+	if err := exec.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if atomic.LoadInt32(&ran) != 3 {
+		t.Errorf("Queued tasks should still be dispatched, got %d", ran)
+	}
+
+	exec2 := NewRateLimitedExecutor(NewBlockingExecutor(2), 1, 1)
+	first := make(chan struct{})
+	exec2.Submit(func() { close(first) })
+	<-first
+	exec2.Submit(func() { t.Error("Pending task should not run") })
+	if pending := exec2.ShutdownNow(); len(pending) != 1 {
+		t.Errorf("Expected 1 pending task, got %d", len(pending))
+	}
+	if err := exec2.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,248 @@
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LockedThreadExecutor 每个 worker 独占一个 OS 线程的执行器，用于要求同一线程调用的 cgo 库等线程亲和的场景
+// worker 启动时调用 runtime.LockOSThread 且不再解锁，同一 worker 上的任务始终在同一个 OS 线程上执行；
+// worker 退出时线程随之销毁，不会带着线程状态回到 Go 的线程池。
+// Submit 提交的任务由任意空闲 worker 执行，Worker(i) / Pin 返回的视图把任务固定到单个 worker，
+// 传给 future 的 *WithExecutor 系列函数后整条链的异步阶段都在该线程上执行。
+// 固定到 worker 的任务不能等待固定到同一 worker 的任务，否则会死锁。
+// 线程亲和的任务无法转移到其他线程，任务内的托管阻塞（如嵌套 Join）只会报告给 SetBlockingDebug，不会补偿 worker
+type LockedThreadExecutor struct {
+	lifecycle
+	metrics
+	workers []*lockedWorker
+	shared  []job // 未固定 worker 的任务
+	queued  int
+	alive   int
+	next    atomic.Uint64 // Pin 的轮询位置
+	blockCounter
+}
+
+type lockedWorker struct {
+	e     *LockedThreadExecutor
+	cond  *sync.Cond // 与 lifecycle.mu 配合使用
+	queue []job      // 固定到该 worker 的任务
+	idle  bool
+}
+
+// NewLockedThreadExecutor 创建 n 个锁定 OS 线程的 worker，n <= 0 时为 runtime.NumCPU()
+func NewLockedThreadExecutor(n int) *LockedThreadExecutor {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	e := &LockedThreadExecutor{
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "locked"},
+		workers:   make([]*lockedWorker, n),
+		alive:     n,
+	}
+	for i := range e.workers {
+		w := &lockedWorker{e: e, cond: sync.NewCond(&e.mu)}
+		e.workers[i] = w
+		go w.work()
+	}
+	return e
+}
+
+// Submit 提交任务，由任意空闲 worker 执行
+func (e *LockedThreadExecutor) Submit(task Runnable) {
+	_ = e.SubmitCtx(context.Background(), task)
+}
+
+// SubmitCtx 提交任务，由任意空闲 worker 执行，开始前 ctx 已结束的任务会被丢弃
+func (e *LockedThreadExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	return e.submit(ctx, nil, task)
+}
+
+// TrySubmit 队列无界，除关闭外不会拒绝，等同于 SubmitCtx
+func (e *LockedThreadExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	return e.SubmitCtx(ctx, task)
+}
+
+// SubmitTo 提交固定在第 i 个 worker 上执行的任务，i 越界时 panic
+func (e *LockedThreadExecutor) SubmitTo(ctx context.Context, i int, task Runnable) error {
+	return e.submit(ctx, e.workers[i], task)
+}
+
+// Worker 返回固定到第 i 个 worker 的 Executor 视图，i 越界时 panic
+func (e *LockedThreadExecutor) Worker(i int) ContextExecutor {
+	return lockedView{w: e.workers[i]}
+}
+
+// Pin 按轮询选一个 worker 并返回固定到它的视图，用于把一条 future 链分配到某个线程上
+func (e *LockedThreadExecutor) Pin() ContextExecutor {
+	i := (e.next.Add(1) - 1) % uint64(len(e.workers))
+	return e.Worker(int(i))
+}
+
+// Size 返回 worker 数量
+func (e *LockedThreadExecutor) Size() int {
+	return len(e.workers)
+}
+
+// Stats 返回运行指标
+func (e *LockedThreadExecutor) Stats() Stats {
+	s := e.snapshot()
+	e.mu.RLock()
+	s.Workers = e.alive
+	s.QueuedTasks = e.queued
+	e.mu.RUnlock()
+	return s
+}
+
+// Shutdown 停止接受新任务，队列中的任务执行完后 worker 退出并销毁其线程
+func (e *LockedThreadExecutor) Shutdown() {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	e.mu.Unlock()
+	e.wakeAll()
+}
+
+// ShutdownNow 停止接受新任务，返回尚未开始的任务（先是未固定的任务，再按 worker 顺序）
+func (e *LockedThreadExecutor) ShutdownNow() []Runnable {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	jobs := e.shared
+	e.shared = nil
+	for _, w := range e.workers {
+		jobs = append(jobs, w.queue...)
+		w.queue = nil
+	}
+	e.queued = 0
+	e.mu.Unlock()
+	e.wakeAll()
+
+	e.rejected.Add(uint64(len(jobs)))
+	pending := make([]Runnable, 0, len(jobs))
+	for _, j := range jobs {
+		NotifyRejected(j.ctx, ErrShutdown)
+		pending = append(pending, j.task)
+	}
+	return pending
+}
+
+func (e *LockedThreadExecutor) submit(ctx context.Context, w *lockedWorker, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	j := job{ctx: ctx, task: task, enqueued: time.Now()}
+
+	e.mu.Lock()
+	if e.shutdown {
+		e.mu.Unlock()
+		e.rejected.Add(1)
+		return ErrShutdown
+	}
+	if w != nil {
+		w.queue = append(w.queue, j)
+	} else {
+		e.shared = append(e.shared, j)
+		// 唤醒一个空闲 worker，都在忙时由最先空闲下来的 worker 取走
+		for _, idle := range e.workers {
+			if idle.idle {
+				// 立即清除标记，连续提交时唤醒不同的 worker
+				idle.idle = false
+				w = idle
+				break
+			}
+		}
+	}
+	e.queued++
+	e.mu.Unlock()
+
+	e.submitted.Add(1)
+	if w != nil {
+		w.cond.Signal()
+	}
+	return nil
+}
+
+// beginBlocking 只计数用于调试报告，不补偿 worker
+func (e *LockedThreadExecutor) beginBlocking() (int, bool) {
+	return e.enter(false)
+}
+
+func (e *LockedThreadExecutor) endBlocking(compensated bool) {
+	e.exit(compensated)
+}
+
+func (e *LockedThreadExecutor) wakeAll() {
+	for _, w := range e.workers {
+		w.cond.Signal()
+	}
+}
+
+// nextLocked 优先取固定到本 worker 的任务，再取未固定的任务
+func (w *lockedWorker) nextLocked() (job, bool) {
+	e := w.e
+	var j job
+	switch {
+	case len(w.queue) > 0:
+		j = w.queue[0]
+		w.queue[0] = job{}
+		w.queue = w.queue[1:]
+	case len(e.shared) > 0:
+		j = e.shared[0]
+		e.shared[0] = job{}
+		e.shared = e.shared[1:]
+	default:
+		return job{}, false
+	}
+	e.queued--
+	return j, true
+}
+
+func (w *lockedWorker) work() {
+	// 不调用 UnlockOSThread：goroutine 退出时线程随之销毁，线程上的 cgo 状态不会泄漏给其他 goroutine
+	runtime.LockOSThread()
+
+	e := w.e
+	e.mu.Lock()
+	for {
+		j, ok := w.nextLocked()
+		if !ok {
+			if e.shutdown {
+				e.alive--
+				if e.alive == 0 {
+					e.terminate()
+				}
+				e.mu.Unlock()
+				return
+			}
+			w.idle = true
+			w.cond.Wait()
+			w.idle = false
+			continue
+		}
+		e.mu.Unlock()
+
+		if err := j.ctx.Err(); err != nil {
+			e.rejected.Add(1)
+			NotifyRejected(j.ctx, err)
+		} else {
+			e.run(j.ctx, j.task, j.enqueued)
+		}
+		e.mu.Lock()
+	}
+}
+
+// lockedView 固定到单个 worker 的执行器
+type lockedView struct {
+	w *lockedWorker
+}
+
+func (v lockedView) Submit(task Runnable) {
+	_ = v.w.e.submit(context.Background(), v.w, task)
+}
+
+func (v lockedView) SubmitCtx(ctx context.Context, task Runnable) error {
+	return v.w.e.submit(ctx, v.w, task)
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package pool
+
+import (
+	"runtime"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+func TestLockedThreadExecutor_PinnedTasksShareThread(t *testing.T) {
+	e := NewLockedThreadExecutor(3)
+	defer e.Shutdown()
+
+	threads := make(map[int]int) // 线程 id -> worker
+	for i := 0; i < e.Size(); i++ {
+		w := e.Worker(i)
+		tids := make(chan int, 10)
+		var wg sync.WaitGroup
+		for j := 0; j < cap(tids); j++ {
+			wg.Add(1)
+			w.Submit(func() {
+				defer wg.Done()
+				// 让出处理器，未锁定线程的 goroutine 恢复后可能换到其他线程
+				runtime.Gosched()
+				tids <- syscall.Gettid()
+			})
+		}
+		wg.Wait()
+		close(tids)
+		first := <-tids
+		for tid := range tids {
+			if tid != first {
+				t.Fatalf("Worker %d ran tasks on threads %d and %d", i, first, tid)
+			}
+		}
+		if other, ok := threads[first]; ok {
+			t.Fatalf("Workers %d and %d share thread %d", other, i, first)
+		}
+		threads[first] = i
+	}
+}
@@ -0,0 +1,59 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestLockedThreadExecutor_SharedTasksUseAllWorkers(t *testing.T) {
+	e := NewLockedThreadExecutor(3)
+	defer e.Shutdown()
+
+	// 三个任务互相等待，只有分配到不同 worker 才能全部完成
+	var wg sync.WaitGroup
+	wg.Add(3)
+	var done sync.WaitGroup
+	done.Add(3)
+	for i := 0; i < 3; i++ {
+		e.Submit(func() {
+			defer done.Done()
+			wg.Done()
+			wg.Wait()
+		})
+	}
+	ch := make(chan struct{})
+	go func() { done.Wait(); close(ch) }()
+	waitClosed(t, ch, "Shared tasks were not spread across workers")
+}
+
+func TestLockedThreadExecutor_Shutdown(t *testing.T) {
+	e := NewLockedThreadExecutor(1)
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-gate })
+	<-started
+
+	var rejected error
+	ctx := WithRejectHandler(context.Background(), func(err error) { rejected = err })
+	_ = e.Worker(0).SubmitCtx(ctx, func() { t.Error("Pending task must not run") })
+	if pending := e.ShutdownNow(); len(pending) != 1 {
+		t.Fatalf("Expected 1 pending task, got %d", len(pending))
+	}
+	if !errors.Is(rejected, ErrShutdown) {
+		t.Errorf("Expected ErrShutdown, got %v", rejected)
+	}
+	if err := e.SubmitCtx(context.Background(), func() {}); !errors.Is(err, ErrShutdown) {
+		t.Errorf("Expected ErrShutdown after shutdown, got %v", err)
+	}
+
+	close(gate)
+	if err := e.AwaitTermination(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := e.Stats(); s.Workers != 0 || s.Completed != 1 {
+		t.Errorf("Unexpected stats %+v", s)
+	}
+}
@@ -0,0 +1,98 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// ManualExecutor 由调用方手动驱动的执行器，用于编写确定性的测试
+// 提交的任务只会排队，直到调用 RunNext / RunAll / RunUntilIdle 时在调用方 goroutine 中按提交顺序执行。
+// 任务中的 panic 不会被恢复，直接传播给调用方
+type ManualExecutor struct {
+	mu    sync.Mutex
+	queue []job
+}
+
+// NewManualExecutor 创建手动驱动的执行器
+func NewManualExecutor() *ManualExecutor {
+	return &ManualExecutor{}
+}
+
+func (e *ManualExecutor) Submit(task Runnable) {
+	_ = e.SubmitCtx(context.Background(), task)
+}
+
+// SubmitCtx 任务运行前 ctx 已结束时不执行，并通知拒绝回调
+func (e *ManualExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e.mu.Lock()
+	e.queue = append(e.queue, job{ctx: ctx, task: task})
+	e.mu.Unlock()
+	return nil
+}
+
+// RunNext 执行队首的一个任务，队列为空时返回 false
+func (e *ManualExecutor) RunNext() bool {
+	e.mu.Lock()
+	if len(e.queue) == 0 {
+		e.mu.Unlock()
+		return false
+	}
+	j := e.queue[0]
+	e.queue[0] = job{}
+	e.queue = e.queue[1:]
+	e.mu.Unlock()
+
+	if err := j.ctx.Err(); err != nil {
+		NotifyRejected(j.ctx, err)
+		return true
+	}
+	j.task()
+	return true
+}
+
+// RunAll 执行调用时已在队列中的任务，执行过程中新提交的任务留在队列中，返回执行的任务数
+func (e *ManualExecutor) RunAll() int {
+	n := e.Pending()
+	for i := 0; i < n; i++ {
+		e.RunNext()
+	}
+	return n
+}
+
+// RunUntilIdle 持续执行直到队列为空，包括执行过程中新提交的任务，返回执行的任务数
+func (e *ManualExecutor) RunUntilIdle() int {
+	n := 0
+	for e.RunNext() {
+		n++
+	}
+	return n
+}
+
+// Pending 返回排队中的任务数
+func (e *ManualExecutor) Pending() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.queue)
+}
+
+// SyncExecutor 在提交方 goroutine 中同步执行任务，Submit 返回时任务已经执行完毕
+// 任务中的 panic 不会被恢复，直接传播给提交方
+type SyncExecutor struct{}
+
+func (SyncExecutor) Submit(task Runnable) {
+	task()
+}
+
+// SubmitCtx ctx 已结束时不执行任务，返回 ctx.Err()
+func (SyncExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	task()
+	return nil
+}
@@ -0,0 +1,68 @@
+package pool
+
+import (
+	"context"
+	"testing"
+)
+
+func TestManualExecutor(t *testing.T) {
+	e := NewManualExecutor()
+	var order []int
+	e.Submit(func() {
+		order = append(order, 1)
+		e.Submit(func() { order = append(order, 3) })
+	})
+	e.Submit(func() { order = append(order, 2) })
+
+	if len(order) != 0 {
+		t.Fatal("Tasks must not run before the executor is driven")
+	}
+	if !e.RunNext() || len(order) != 1 {
+		t.Fatalf("RunNext should run exactly one task, got %v", order)
+	}
+	if n := e.RunAll(); n != 2 || e.Pending() != 0 {
+		t.Fatalf("RunAll should run the 2 queued tasks, ran %d, pending %d", n, e.Pending())
+	}
+
+	e.Submit(func() { e.Submit(func() { order = append(order, 4) }) })
+	if n := e.RunUntilIdle(); n != 2 {
+		t.Fatalf("RunUntilIdle should also run newly submitted tasks, ran %d", n)
+	}
+	if e.RunNext() {
+		t.Error("RunNext on an empty queue should return false")
+	}
+	want := []int{1, 2, 3, 4}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestManualExecutor_SkipsCancelled(t *testing.T) {
+	e := NewManualExecutor()
+	ctx, cancel := context.WithCancel(context.Background())
+	var rejected error
+	_ = e.SubmitCtx(WithRejectHandler(ctx, func(err error) { rejected = err }), func() {
+		t.Error("Cancelled task should not run")
+	})
+	cancel()
+	e.RunUntilIdle()
+	if rejected != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", rejected)
+	}
+}
+
+func TestSyncExecutor(t *testing.T) {
+	ran := false
+	SyncExecutor{}.Submit(func() { ran = true })
+	if !ran {
+		t.Error("SyncExecutor should run the task inline")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := (SyncExecutor{}).SubmitCtx(ctx, func() { t.Error("Should not run") }); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package pool
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// Middleware 任务中间件，在提交时包装任务，为其附加计时、标签、追踪等横切逻辑
+type Middleware func(next Runnable) Runnable
+
+// ChainExecutor 用中间件包装每个提交任务的执行器
+// 底层执行器不支持 SubmitCtx / TrySubmit 时降级为 Submit
+type ChainExecutor struct {
+	base        Executor
+	middlewares []Middleware
+}
+
+// Chain 返回在 base 上应用中间件的执行器，排在前面的中间件位于最外层，最先开始、最后结束
+func Chain(base Executor, middlewares ...Middleware) *ChainExecutor {
+	if base == nil {
+		panic("pool: base executor cannot be nil")
+	}
+	// 嵌套的 Chain 合并为一层，避免重复的类型断言与转发
+	if c, ok := base.(*ChainExecutor); ok {
+		base = c.base
+		middlewares = append(append([]Middleware(nil), c.middlewares...), middlewares...)
+	}
+	return &ChainExecutor{base: base, middlewares: middlewares}
+}
+
+// Unwrap 返回底层执行器，可用于关闭或读取指标
+func (c *ChainExecutor) Unwrap() Executor {
+	return c.base
+}
+
+func (c *ChainExecutor) Submit(task Runnable) {
+	c.base.Submit(c.wrap(task))
+}
+
+func (c *ChainExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	if ce, ok := c.base.(ContextExecutor); ok {
+		return ce.SubmitCtx(ctx, c.wrap(task))
+	}
+	c.base.Submit(c.wrap(task))
+	return nil
+}
+
+func (c *ChainExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	if es, ok := c.base.(ExecutorService); ok {
+		return es.TrySubmit(ctx, c.wrap(task))
+	}
+	return c.SubmitCtx(ctx, task)
+}
+
+// SubmitWeighted 底层执行器不支持权重时忽略权重
+func (c *ChainExecutor) SubmitWeighted(ctx context.Context, weight int, task Runnable) error {
+	if we, ok := c.base.(WeightedExecutor); ok {
+		return we.SubmitWeighted(ctx, weight, c.wrap(task))
+	}
+	return c.SubmitCtx(ctx, task)
+}
+
+func (c *ChainExecutor) wrap(task Runnable) Runnable {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		task = c.middlewares[i](task)
+	}
+	return task
+}
+
+// Timing 记录任务的排队时间（从提交到开始）与执行耗时，任务 panic 时同样会记录
+func Timing(observe func(wait, run time.Duration)) Middleware {
+	return func(next Runnable) Runnable {
+		submitted := time.Now()
+		return func() {
+			start := time.Now()
+			defer func() { observe(start.Sub(submitted), time.Since(start)) }()
+			next()
+		}
+	}
+}
+
+// Labels 为任务附加 pprof 标签，CPU profile 中可按标签过滤，如 Labels("component", "billing")
+// keyvals 为成对的键值，个数为奇数时忽略最后一个
+func Labels(keyvals ...string) Middleware {
+	if len(keyvals)%2 != 0 {
+		keyvals = keyvals[:len(keyvals)-1]
+	}
+	labels := pprof.Labels(keyvals...)
+	return func(next Runnable) Runnable {
+		return func() {
+			pprof.Do(context.Background(), labels, func(context.Context) { next() })
+		}
+	}
+}
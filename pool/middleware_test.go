@@ -0,0 +1,94 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestChain_Order(t *testing.T) {
+	var mu sync.Mutex
+	var trace []string
+	mw := func(name string) Middleware {
+		return func(next Runnable) Runnable {
+			return func() {
+				mu.Lock()
+				trace = append(trace, name+">")
+				mu.Unlock()
+				next()
+				mu.Lock()
+				trace = append(trace, "<"+name)
+				mu.Unlock()
+			}
+		}
+	}
+
+	exec := Chain(Chain(NewBlockingExecutor(1), mw("a")), mw("b"))
+	done := make(chan struct{})
+	exec.Submit(func() {
+		mu.Lock()
+		trace = append(trace, "task")
+		mu.Unlock()
+		close(done)
+	})
+	<-done
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"a>", "b>", "task", "<b", "<a"}
+	for i := range want {
+		if i >= len(trace) || trace[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, trace)
+		}
+	}
+}
+
+func TestChain_ForwardsContextSubmission(t *testing.T) {
+	base := NewWorkerPool(1, 0)
+	defer base.Shutdown()
+	exec := Chain(base)
+
+	release := make(chan struct{})
+	defer close(release)
+	_ = exec.SubmitCtx(context.Background(), func() { <-release })
+	time.Sleep(10 * time.Millisecond)
+
+	if err := exec.TrySubmit(context.Background(), func() {}); err != ErrRejected {
+		t.Errorf("TrySubmit should reach the base executor, got %v", err)
+	}
+	if exec.Unwrap() != Executor(base) {
+		t.Error("Unwrap should return the base executor")
+	}
+}
+
+func TestTimingAndLabels(t *testing.T) {
+	type result struct {
+		wait, run time.Duration
+		profile   string
+	}
+	results := make(chan result, 1)
+	var profile bytes.Buffer
+
+	exec := Chain(&DirectExecutor{},
+		Timing(func(wait, run time.Duration) { results <- result{wait, run, profile.String()} }),
+		Labels("component", "billing", "dangling"),
+	)
+	exec.Submit(func() {
+		// goroutine profile 会带上当前 goroutine 的标签
+		_ = pprof.Lookup("goroutine").WriteTo(&profile, 1)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	r := <-results
+	if r.run < 10*time.Millisecond || r.wait < 0 {
+		t.Errorf("Unexpected timing: wait=%v run=%v", r.wait, r.run)
+	}
+	if !strings.Contains(r.profile, `"component":"billing"`) {
+		t.Error("Task goroutine should carry the pprof label component=billing")
+	}
+}
@@ -0,0 +1,236 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	runtimemetrics "runtime/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrOverloaded 执行器过载，任务被拒绝；具体原因见 *OverloadError
+var ErrOverloaded = errors.New("pool: overloaded")
+
+// OverloadError 内存压力超过阈值时拒绝任务返回的错误，errors.Is(err, ErrOverloaded) 为 true
+type OverloadError struct {
+	// Pressure 拒绝时观测到的内存压力
+	Pressure MemoryPressure
+	// MaxHeapBytes / MaxGCCPUFraction 配置的阈值
+	MaxHeapBytes     uint64
+	MaxGCCPUFraction float64
+}
+
+func (e *OverloadError) Error() string {
+	return fmt.Sprintf("pool: overloaded (heap %d bytes, limit %d; gc cpu %.2f, limit %.2f)",
+		e.Pressure.HeapBytes, e.MaxHeapBytes, e.Pressure.GCCPUFraction, e.MaxGCCPUFraction)
+}
+
+func (e *OverloadError) Is(target error) bool {
+	return target == ErrOverloaded
+}
+
+// MemoryPressure 从 runtime/metrics 读取的内存压力
+type MemoryPressure struct {
+	// HeapBytes 堆上对象占用的字节数 (/memory/classes/heap/objects:bytes)
+	HeapBytes uint64
+	// GCCPUFraction 最近一个采样周期内 GC 占用的 CPU 比例
+	GCCPUFraction float64
+}
+
+// MemoryGuardConfig 内存压力保护配置，阈值为 0 表示不检查该项
+type MemoryGuardConfig struct {
+	// MaxHeapBytes 堆大小阈值
+	MaxHeapBytes uint64
+	// MaxGCCPUFraction GC CPU 占比阈值，取值 (0, 1]
+	MaxGCCPUFraction float64
+	// SampleInterval 采样间隔，间隔内的提交复用上一次的采样结果，默认 100ms
+	SampleInterval time.Duration
+	// MaxDelay 超过阈值时最多延迟提交多久等待压力回落，仍未回落再拒绝；0 表示立即拒绝
+	MaxDelay time.Duration
+}
+
+// MemoryGuard 根据内存压力削减负载的执行器装饰器
+// 提交时检查堆大小与 GC CPU 占比，超过阈值的任务被延迟或以 *OverloadError 拒绝，通过 future 提交时 Future 以该错误失败
+type MemoryGuard struct {
+	base Executor
+	cfg  MemoryGuardConfig
+
+	mu       sync.Mutex
+	read     func() MemoryPressure // 测试时可替换
+	sampled  atomic.Int64          // 上次采样时间 (UnixNano)
+	pressure atomic.Pointer[MemoryPressure]
+
+	shed atomic.Uint64
+}
+
+// NewMemoryGuard 创建基于 base 的内存压力保护执行器，base 为 nil 时使用当前的全局执行器
+func NewMemoryGuard(base Executor, cfg MemoryGuardConfig) *MemoryGuard {
+	if base == nil {
+		base = Global()
+	}
+	if cfg.SampleInterval <= 0 {
+		cfg.SampleInterval = 100 * time.Millisecond
+	}
+	g := &MemoryGuard{
+		base: base,
+		cfg:  cfg,
+		read: newRuntimeSampler(),
+	}
+	g.pressure.Store(&MemoryPressure{})
+	return g
+}
+
+// Unwrap 返回底层执行器
+func (g *MemoryGuard) Unwrap() Executor {
+	return g.base
+}
+
+// Submit 提交任务，过载时任务被丢弃，需要感知拒绝请使用 SubmitCtx
+func (g *MemoryGuard) Submit(task Runnable) {
+	_ = g.SubmitCtx(context.Background(), task)
+}
+
+// SubmitCtx 提交任务，过载时最多等待 MaxDelay，仍过载返回 *OverloadError；等待期间 ctx 结束返回 ctx.Err()
+func (g *MemoryGuard) SubmitCtx(ctx context.Context, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := g.admit(ctx, g.cfg.MaxDelay); err != nil {
+		return err
+	}
+	if ce, ok := g.base.(ContextExecutor); ok {
+		return ce.SubmitCtx(ctx, task)
+	}
+	g.base.Submit(task)
+	return nil
+}
+
+// TrySubmit 过载时不等待，直接返回 *OverloadError
+func (g *MemoryGuard) TrySubmit(ctx context.Context, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := g.admit(ctx, 0); err != nil {
+		return err
+	}
+	if es, ok := g.base.(ExecutorService); ok {
+		return es.TrySubmit(ctx, task)
+	}
+	if ce, ok := g.base.(ContextExecutor); ok {
+		return ce.SubmitCtx(ctx, task)
+	}
+	g.base.Submit(task)
+	return nil
+}
+
+// Pressure 返回最近一次采样的内存压力
+func (g *MemoryGuard) Pressure() MemoryPressure {
+	return *g.pressure.Load()
+}
+
+// Shed 返回因过载被拒绝的任务数
+func (g *MemoryGuard) Shed() uint64 {
+	return g.shed.Load()
+}
+
+// SetPanicHandler 任务由底层执行器执行，底层执行器实现 PanicHandlerSetter 时转发给它
+func (g *MemoryGuard) SetPanicHandler(h PanicHandler) {
+	if s, ok := g.base.(PanicHandlerSetter); ok {
+		s.SetPanicHandler(h)
+	}
+}
+
+// SetTiming 任务由底层执行器执行，底层执行器实现 TimingSetter 时转发给它
+func (g *MemoryGuard) SetTiming(enabled bool) {
+	if s, ok := g.base.(TimingSetter); ok {
+		s.SetTiming(enabled)
+	}
+}
+
+// admit 压力未超过阈值时放行，否则每个采样周期重新检查一次，直到超过 delay
+func (g *MemoryGuard) admit(ctx context.Context, delay time.Duration) error {
+	p := g.sample()
+	if !g.overloaded(p) {
+		return nil
+	}
+	if delay > 0 {
+		deadline := time.Now().Add(delay)
+		timer := time.NewTimer(min(g.cfg.SampleInterval, delay))
+		defer timer.Stop()
+		for g.overloaded(p) {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				break
+			}
+			timer.Reset(min(g.cfg.SampleInterval, remaining))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			p = g.sample()
+		}
+		if !g.overloaded(p) {
+			return nil
+		}
+	}
+	g.shed.Add(1)
+	return &OverloadError{
+		Pressure:         p,
+		MaxHeapBytes:     g.cfg.MaxHeapBytes,
+		MaxGCCPUFraction: g.cfg.MaxGCCPUFraction,
+	}
+}
+
+func (g *MemoryGuard) overloaded(p MemoryPressure) bool {
+	if g.cfg.MaxHeapBytes > 0 && p.HeapBytes > g.cfg.MaxHeapBytes {
+		return true
+	}
+	return g.cfg.MaxGCCPUFraction > 0 && p.GCCPUFraction > g.cfg.MaxGCCPUFraction
+}
+
+// sample 返回当前压力，距上次采样不足 SampleInterval 时复用上次结果
+func (g *MemoryGuard) sample() MemoryPressure {
+	now := time.Now().UnixNano()
+	if now-g.sampled.Load() < int64(g.cfg.SampleInterval) {
+		return *g.pressure.Load()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// 等锁期间其他提交者可能已经完成采样
+	if now-g.sampled.Load() < int64(g.cfg.SampleInterval) {
+		return *g.pressure.Load()
+	}
+	p := g.read()
+	g.pressure.Store(&p)
+	g.sampled.Store(time.Now().UnixNano())
+	return p
+}
+
+// newRuntimeSampler 返回读取 runtime/metrics 的采样函数，调用方需保证串行调用
+// GC CPU 占比由两次采样之间 GC 与总 CPU 时间的增量计算
+func newRuntimeSampler() func() MemoryPressure {
+	samples := []runtimemetrics.Sample{
+		{Name: "/memory/classes/heap/objects:bytes"},
+		{Name: "/cpu/classes/gc/total:cpu-seconds"},
+		{Name: "/cpu/classes/total:cpu-seconds"},
+	}
+	var lastGC, lastTotal float64
+	return func() MemoryPressure {
+		runtimemetrics.Read(samples)
+		var p MemoryPressure
+		if samples[0].Value.Kind() == runtimemetrics.KindUint64 {
+			p.HeapBytes = samples[0].Value.Uint64()
+		}
+		if samples[1].Value.Kind() == runtimemetrics.KindFloat64 && samples[2].Value.Kind() == runtimemetrics.KindFloat64 {
+			gc, total := samples[1].Value.Float64(), samples[2].Value.Float64()
+			if d := total - lastTotal; lastTotal > 0 && d > 0 {
+				p.GCCPUFraction = (gc - lastGC) / d
+			}
+			lastGC, lastTotal = gc, total
+		}
+		return p
+	}
+}
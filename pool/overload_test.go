@@ -0,0 +1,114 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakePressure 返回可由测试修改的内存压力
+func fakePressure(g *MemoryGuard, heap *atomic.Uint64) {
+	g.read = func() MemoryPressure {
+		return MemoryPressure{HeapBytes: heap.Load()}
+	}
+}
+
+func TestMemoryGuard_RejectsAboveThreshold(t *testing.T) {
+	g := NewMemoryGuard(NewBlockingExecutor(4), MemoryGuardConfig{
+		MaxHeapBytes:   100,
+		SampleInterval: time.Nanosecond,
+	})
+	var heap atomic.Uint64
+	fakePressure(g, &heap)
+
+	heap.Store(50)
+	done := make(chan struct{})
+	if err := g.SubmitCtx(context.Background(), func() { close(done) }); err != nil {
+		t.Fatalf("Expected admission below threshold, got %v", err)
+	}
+	<-done
+
+	heap.Store(200)
+	ran := false
+	err := g.TrySubmit(context.Background(), func() { ran = true })
+	if !errors.Is(err, ErrOverloaded) {
+		t.Fatalf("Expected ErrOverloaded, got %v", err)
+	}
+	var oe *OverloadError
+	if !errors.As(err, &oe) || oe.Pressure.HeapBytes != 200 || oe.MaxHeapBytes != 100 {
+		t.Fatalf("Unexpected overload error %#v", err)
+	}
+	if ran {
+		t.Error("Rejected task must not run")
+	}
+	if g.Shed() != 1 {
+		t.Errorf("Expected 1 shed task, got %d", g.Shed())
+	}
+}
+
+func TestMemoryGuard_DelaysUntilPressureDrops(t *testing.T) {
+	g := NewMemoryGuard(NewBlockingExecutor(4), MemoryGuardConfig{
+		MaxHeapBytes:   100,
+		SampleInterval: time.Millisecond,
+		MaxDelay:       time.Second,
+	})
+	var heap atomic.Uint64
+	fakePressure(g, &heap)
+	heap.Store(200)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		heap.Store(50)
+	}()
+	done := make(chan struct{})
+	if err := g.SubmitCtx(context.Background(), func() { close(done) }); err != nil {
+		t.Fatalf("Expected admission after pressure dropped, got %v", err)
+	}
+	<-done
+	if g.Shed() != 0 {
+		t.Errorf("Expected no shed tasks, got %d", g.Shed())
+	}
+
+	// 压力一直不回落时超过 MaxDelay 后拒绝，ctx 先结束时返回 ctx 的错误
+	heap.Store(200)
+	time.Sleep(2 * g.cfg.SampleInterval) // 让缓存的样本过期
+	g.cfg.MaxDelay = 5 * time.Millisecond
+	if err := g.SubmitCtx(context.Background(), func() {}); !errors.Is(err, ErrOverloaded) {
+		t.Fatalf("Expected ErrOverloaded after MaxDelay, got %v", err)
+	}
+	g.cfg.MaxDelay = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := g.SubmitCtx(ctx, func() {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMemoryGuard_CachesSamples(t *testing.T) {
+	g := NewMemoryGuard(NewBlockingExecutor(4), MemoryGuardConfig{SampleInterval: time.Hour})
+	var reads atomic.Int32
+	g.read = func() MemoryPressure {
+		reads.Add(1)
+		return MemoryPressure{}
+	}
+	for i := 0; i < 10; i++ {
+		_ = g.SubmitCtx(context.Background(), func() {})
+	}
+	if n := reads.Load(); n != 1 {
+		t.Errorf("Expected a single sample within the interval, got %d", n)
+	}
+}
+
+func TestMemoryGuard_RuntimeSampler(t *testing.T) {
+	read := newRuntimeSampler()
+	_ = read()
+	p := read()
+	if p.HeapBytes == 0 {
+		t.Error("Expected a non-zero heap size")
+	}
+	if p.GCCPUFraction < 0 || p.GCCPUFraction > 1 {
+		t.Errorf("GC CPU fraction out of range: %v", p.GCCPUFraction)
+	}
+}
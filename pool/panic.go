@@ -0,0 +1,108 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// PanicInfo 任务 panic 时传给 PanicHandler 的信息
+type PanicInfo struct {
+	// Executor 执行器类型，如 "blocking"、"worker_pool"
+	Executor string
+	// Value recover() 得到的值
+	Value any
+	// Stack panic 发生时任务 goroutine 的调用栈
+	Stack []byte
+	// Context 提交任务时的 context，可从中读取 trace id、优先级等元数据；Submit 提交的任务为 context.Background()
+	Context context.Context
+	// Enqueued 任务提交时间，Started 任务开始执行时间
+	Enqueued time.Time
+	Started  time.Time
+}
+
+// PanicHandler 处理任务中恢复的 panic，在发生 panic 的 goroutine 中同步调用
+// 处理器自身的 panic 会被忽略，不会导致 worker 退出
+type PanicHandler func(info PanicInfo)
+
+// PanicHandlerSetter 支持单独设置 panic 处理器的执行器，内置执行器均已实现
+type PanicHandlerSetter interface {
+	SetPanicHandler(h PanicHandler)
+}
+
+var globalPanicHandler atomic.Pointer[PanicHandler]
+
+// SetPanicHandler 设置全局 panic 处理器，未单独设置处理器的执行器都会使用它
+// 传入 nil 恢复默认的 slog 处理器
+func SetPanicHandler(h PanicHandler) {
+	if h == nil {
+		globalPanicHandler.Store(nil)
+		return
+	}
+	globalPanicHandler.Store(&h)
+}
+
+// SlogPanicHandler 返回将 panic 以 Error 级别写入 logger 的处理器，logger 为 nil 时使用 slog.Default()
+func SlogPanicHandler(logger *slog.Logger) PanicHandler {
+	return func(info PanicInfo) {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		ctx := info.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		l.ErrorContext(ctx, "[Pool] Panic recovered",
+			slog.String("executor", info.Executor),
+			slog.String("panic", fmt.Sprint(info.Value)),
+			slog.Duration("queue_wait", info.Started.Sub(info.Enqueued)),
+			slog.String("stack", string(info.Stack)),
+		)
+	}
+}
+
+var defaultPanicHandler = SlogPanicHandler(nil)
+
+// SetPanicHandler 为当前执行器单独设置 panic 处理器，传入 nil 回退到全局处理器
+func (m *metrics) SetPanicHandler(h PanicHandler) {
+	if h == nil {
+		m.panicHandler.Store(nil)
+		return
+	}
+	m.panicHandler.Store(&h)
+}
+
+// handlePanic 按 执行器处理器 -> 全局处理器 -> 默认处理器 的顺序选择并调用
+func (m *metrics) handlePanic(info PanicInfo) {
+	h := defaultPanicHandler
+	if p := m.panicHandler.Load(); p != nil {
+		h = *p
+	} else if p := globalPanicHandler.Load(); p != nil {
+		h = *p
+	}
+	defer func() { _ = recover() }()
+	h(info)
+}
+
+// runSafely 执行任务并恢复 panic，防止单个任务导致 worker 退出或进程崩溃
+func (m *metrics) runSafely(ctx context.Context, task Runnable, enqueued, started time.Time) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			m.handlePanic(PanicInfo{
+				Executor: m.name,
+				Value:    r,
+				Stack:    debug.Stack(),
+				Context:  ctx,
+				Enqueued: enqueued,
+				Started:  started,
+			})
+		}
+	}()
+	task()
+	return false
+}
@@ -0,0 +1,113 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type traceKey struct{}
+
+func TestPanicHandler_PerExecutor(t *testing.T) {
+	infos := make(chan PanicInfo, 1)
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{
+		MinWorkers:   1,
+		QueueSize:    1,
+		PanicHandler: func(info PanicInfo) { infos <- info },
+	})
+	defer p.Shutdown()
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "trace-1")
+	_ = p.SubmitCtx(ctx, func() { panic("boom") })
+
+	select {
+	case info := <-infos:
+		if info.Value != "boom" || info.Executor != "worker_pool" {
+			t.Errorf("Unexpected panic info: %+v", info)
+		}
+		if info.Context.Value(traceKey{}) != "trace-1" {
+			t.Error("Handler should receive the task context")
+		}
+		if !bytes.Contains(info.Stack, []byte("TestPanicHandler_PerExecutor")) {
+			t.Errorf("Stack should point at the panicking task:\n%s", info.Stack)
+		}
+		if info.Started.Before(info.Enqueued) {
+			t.Error("Started should not be before Enqueued")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Panic handler not called")
+	}
+}
+
+func TestPanicHandler_GlobalAndHandlerPanic(t *testing.T) {
+	var mu sync.Mutex
+	var global []any
+	SetPanicHandler(func(info PanicInfo) {
+		mu.Lock()
+		global = append(global, info.Value)
+		mu.Unlock()
+		panic("handler failed")
+	})
+	defer SetPanicHandler(nil)
+
+	exec := NewBlockingExecutor(1)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	exec.Submit(func() { defer wg.Done(); panic(1) })
+	exec.Submit(func() { defer wg.Done(); panic(2) })
+	wg.Wait()
+
+	// handler 中的 panic 不应影响后续任务
+	done := make(chan struct{})
+	exec.Submit(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Executor stopped working after handler panic")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(global) != 2 {
+		t.Errorf("Expected global handler to see 2 panics, got %v", global)
+	}
+}
+
+func TestSlogPanicHandler(t *testing.T) {
+	var buf bytes.Buffer
+	var mu sync.Mutex
+	logger := slog.New(slog.NewJSONHandler(&lockedWriter{w: &buf, mu: &mu}, nil))
+
+	p := NewPriorityExecutor(1, 0)
+	defer p.Shutdown()
+	p.SetPanicHandler(SlogPanicHandler(logger))
+
+	_ = p.SubmitPriority(0, func() { panic("slog boom") })
+	done := make(chan struct{})
+	_ = p.SubmitPriority(0, func() { close(done) })
+	<-done
+
+	mu.Lock()
+	out := buf.String()
+	mu.Unlock()
+	for _, want := range []string{`"level":"ERROR"`, `"executor":"priority"`, `"panic":"slog boom"`, `"stack":`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Log output missing %s: %s", want, out)
+		}
+	}
+}
+
+type lockedWriter struct {
+	w  *bytes.Buffer
+	mu *sync.Mutex
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -1,9 +1,10 @@
 package pool
 
 import (
-	"log"
+	"context"
 	"runtime"
-	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Runnable 任务函数定义
@@ -15,50 +16,234 @@ type Executor interface {
 	Submit(task Runnable)
 }
 
-// GlobalExecutor 全局默认执行器
-var GlobalExecutor Executor
+var global atomic.Pointer[Executor]
 
-// SetGlobalExecutor 允许用户在 main 函数初始化时替换默认池
-func SetGlobalExecutor(e Executor) {
-	GlobalExecutor = e
+// Global 返回当前的全局默认执行器，可以与 SetGlobalExecutor 并发调用
+func Global() Executor {
+	return *global.Load()
+}
+
+// Resizable 支持在运行时调整并发上限的执行器
+type Resizable interface {
+	SetMaxConcurrency(n int)
+	MaxConcurrency() int
+}
+
+// SetGlobalExecutor 原子地替换全局默认执行器并返回之前的执行器，可以在运行时安全调用
+// e 为 nil 时换回一个新建的默认执行器（见 init）。
+// 已提交到旧执行器的任务不受影响，调用方可以通过其 Lifecycle 关闭并等待它排空，或直接使用 ReplaceGlobalExecutor
+func SetGlobalExecutor(e Executor) Executor {
+	if e == nil {
+		e = newDefaultExecutor()
+	}
+	prev := global.Swap(&e)
+	if prev == nil {
+		return nil
+	}
+	return *prev
+}
+
+// ReplaceGlobalExecutor 替换全局默认执行器，并关闭旧执行器、等待其中已接受的任务执行完毕
+// 旧执行器未实现 Lifecycle 时无法得知其任务何时结束，直接返回 nil
+func ReplaceGlobalExecutor(ctx context.Context, e Executor) error {
+	prev := SetGlobalExecutor(e)
+	l, ok := prev.(Lifecycle)
+	if !ok {
+		return nil
+	}
+	l.Shutdown()
+	return l.AwaitTermination(ctx)
 }
 
 func init() {
-	// 默认并发数为 CPU 核心数 * 2，模拟 Java ForkJoinPool.commonPool
+	SetGlobalExecutor(newDefaultExecutor())
+}
+
+func newDefaultExecutor() Executor {
+	// 默认并发数为 CPU 核心数 * 2，适合 I/O 为主的任务
+	// 注意：这只是信号量限流，递归分治任务请使用 NewForkJoinPool
 	cpus := runtime.NumCPU() * 2
 	if cpus < 4 {
 		cpus = 4
 	}
-	GlobalExecutor = NewBlockingExecutor(cpus)
+	return NewBlockingExecutor(cpus)
 }
 
 // NewBlockingExecutor 创建一个带并发限制的执行器
 func NewBlockingExecutor(limit int) Executor {
+	return NewBlockingExecutorWithPolicy(limit, BlockPolicy)
+}
+
+// NewBlockingExecutorWithPolicy 创建带并发限制的执行器，并发已满时按 policy 处理新任务
+// blockingExecutor 没有任务队列，DiscardOldestPolicy 等同于 AbortPolicy
+func NewBlockingExecutorWithPolicy(limit int, policy RejectionPolicy) ManagedExecutor {
+	if limit < 1 {
+		limit = 1
+	}
 	return &blockingExecutor{
-		sem: make(chan struct{}, limit),
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "blocking"},
+		sem:       newSemaphore(int64(limit)),
+		policy:    policy,
 	}
 }
 
 // blockingExecutor 限制并发数的简单实现
 type blockingExecutor struct {
-	sem  chan struct{} // 信号量
-	wait sync.WaitGroup
+	lifecycle
+	metrics
+	sem     *semaphore
+	policy  RejectionPolicy
+	active  atomic.Int64 // 正在运行或正在等待信号量的任务数
+	closed  atomic.Bool  // lifecycle.shutdown 的无锁副本，提交路径不加锁
+	waiting atomic.Int64 // 阻塞等待信号量的提交者数
+	blockCounter
 }
 
 func (e *blockingExecutor) Submit(task Runnable) {
+	_ = e.SubmitCtx(context.Background(), task)
+}
+
+func (e *blockingExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	return e.SubmitWeighted(ctx, 1, task)
+}
+
+// SubmitWeighted 提交占用 weight 个并发名额的任务，weight < 1 时按 1 处理
+// 名额按提交顺序发放，等待中的重任务不会被后到的轻任务插队；超过并发上限的任务在执行器空闲时独占执行
+func (e *blockingExecutor) SubmitWeighted(ctx context.Context, weight int, task Runnable) error {
+	if e.policy != BlockPolicy {
+		return e.trySubmit(ctx, weight, task)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	n := int64(max(weight, 1))
+	if !e.reserve() {
+		e.rejected.Add(1)
+		return ErrShutdown
+	}
+	enqueued := e.now()
+	// 先尝试非阻塞获取，避免无谓地登记等待者
+	if e.sem.tryAcquire(n) {
+		e.run(ctx, task, enqueued, n)
+		return nil
+	}
 	// 获取信号量，如果满了会阻塞，起到背压作用
-	e.sem <- struct{}{}
+	e.waiting.Add(1)
+	err := e.sem.acquire(ctx, n)
+	e.waiting.Add(-1)
+	if err != nil {
+		e.release()
+		e.rejected.Add(1)
+		return err
+	}
+	e.run(ctx, task, enqueued, n)
+	return nil
+}
+
+func (e *blockingExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	return e.trySubmit(ctx, 1, task)
+}
+
+func (e *blockingExecutor) trySubmit(ctx context.Context, weight int, task Runnable) error {
+	n := int64(max(weight, 1))
+	if !e.reserve() {
+		e.rejected.Add(1)
+		return ErrShutdown
+	}
+	if e.sem.tryAcquire(n) {
+		e.run(ctx, task, e.now(), n)
+		return nil
+	}
+	e.release()
+	return e.reject(e.policy, ctx, task)
+}
+
+// SetMaxConcurrency 运行时调整并发上限，n < 1 时按 1 处理
+// 缩容不会打断正在运行的任务，运行数降到新上限以下后才会启动新任务
+func (e *blockingExecutor) SetMaxConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	e.sem.resize(int64(n))
+}
+
+// MaxConcurrency 返回当前并发上限
+func (e *blockingExecutor) MaxConcurrency() int {
+	return int(e.sem.limit())
+}
+
+// Stats 返回运行指标，QueuedTasks 为阻塞等待信号量的提交者数量
+func (e *blockingExecutor) Stats() Stats {
+	s := e.snapshot()
+	s.QueuedTasks = int(e.waiting.Load())
+	return s
+}
+
+func (e *blockingExecutor) Shutdown() {
+	e.mu.Lock()
+	first := e.beginShutdownLocked()
+	e.mu.Unlock()
+	// 先标记再检查计数，与 reserve 的先计数再检查标记配合，两者至少有一方能看到对方
+	if first {
+		e.closed.Store(true)
+		e.sem.close()
+		if e.active.Load() == 0 {
+			e.terminate()
+		}
+	}
+}
+
+// ShutdownNow blockingExecutor 没有队列，等待信号量的提交者会收到 ErrShutdown
+func (e *blockingExecutor) ShutdownNow() []Runnable {
+	e.Shutdown()
+	return nil
+}
+
+// reserve 登记一个任务，已关闭时返回 false
+func (e *blockingExecutor) reserve() bool {
+	e.active.Add(1)
+	if e.closed.Load() {
+		e.release()
+		return false
+	}
+	return true
+}
+
+func (e *blockingExecutor) release() {
+	if e.active.Add(-1) == 0 && e.closed.Load() {
+		e.terminate()
+	}
+}
+
+// run 在已获取 permits 个许可的前提下启动任务
+func (e *blockingExecutor) run(ctx context.Context, task Runnable, enqueued time.Time, permits int64) {
+	e.submitted.Add(1)
 	go func() {
 		defer func() {
-			<-e.sem // 释放信号量
-			if r := recover(); r != nil {
-				log.Printf("[Pool] Panic recovered: %v", r)
-			}
+			e.sem.release(permits)
+			e.release()
 		}()
-		task()
+		e.metrics.run(ctx, task, enqueued)
 	}()
 }
 
+// beginBlocking 托管阻塞期间放宽信号量，允许多运行一个任务
+func (e *blockingExecutor) beginBlocking() (int, bool) {
+	n, ok := e.enter(true)
+	if ok {
+		e.sem.compensate(1)
+	}
+	return n, ok
+}
+
+func (e *blockingExecutor) endBlocking(compensated bool) {
+	if compensated {
+		e.sem.compensate(-1)
+	}
+	e.exit(compensated)
+}
+
 // DirectExecutor 直接在当前 goroutine 或新 goroutine 执行
 type DirectExecutor struct{}
 
@@ -1,6 +1,7 @@
 package pool
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -87,3 +88,74 @@ func TestBlockingExecutor_PanicSafety(t *testing.T) {
 		t.Fatal("Pool blocked or crashed after panic")
 	}
 }
+
+func TestSetGlobalExecutor_NilRestoresDefault(t *testing.T) {
+	original := SetGlobalExecutor(nil)
+	defer SetGlobalExecutor(original)
+
+	exec := Global()
+	if exec == nil || exec == original {
+		t.Fatalf("Expected a new default executor, got %v", exec)
+	}
+	if _, ok := exec.(*blockingExecutor); !ok {
+		t.Fatalf("Expected the default blocking executor, got %T", exec)
+	}
+	done := make(chan struct{})
+	exec.Submit(func() { close(done) })
+	<-done
+}
+
+// 运行时并发替换全局执行器，在 -race 下不应报告数据竞争，旧执行器中已接受的任务全部执行完
+func TestReplaceGlobalExecutor_Concurrent(t *testing.T) {
+	original := SetGlobalExecutor(NewWorkerPool(2, 16))
+	defer SetGlobalExecutor(original)
+
+	var submitted, ran int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				exec := Global().(ExecutorService)
+				if exec.SubmitCtx(context.Background(), func() { atomic.AddInt64(&ran, 1) }) == nil {
+					atomic.AddInt64(&submitted, 1)
+				}
+			}
+		}()
+	}
+
+	var olds []Lifecycle
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		olds = append(olds, Global().(Lifecycle))
+		if err := ReplaceGlobalExecutor(ctx, NewWorkerPool(2, 16)); err != nil {
+			t.Fatalf("Old executor failed to drain: %v", err)
+		}
+		cancel()
+	}
+	close(stop)
+	wg.Wait()
+
+	olds = append(olds, Global().(Lifecycle))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := ReplaceGlobalExecutor(ctx, original); err != nil {
+		t.Fatalf("ReplaceGlobalExecutor failed: %v", err)
+	}
+	for _, l := range olds {
+		if !l.IsTerminated() {
+			t.Error("Replaced executors should be terminated")
+		}
+	}
+	if atomic.LoadInt64(&ran) != atomic.LoadInt64(&submitted) {
+		t.Errorf("Accepted tasks must not be abandoned: submitted %d, ran %d", submitted, ran)
+	}
+}
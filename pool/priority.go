@@ -0,0 +1,216 @@
+package pool
+
+import (
+	"container/heap"
+	"context"
+	"runtime"
+	"time"
+)
+
+type priorityKey struct{}
+
+// WithPriority 返回携带任务优先级的 context，数值越大越优先
+// 通过 SubmitCtx 提交到 PriorityExecutor 的任务会读取该优先级
+func WithPriority(ctx context.Context, priority int) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom 读取 ctx 上的优先级，未设置时返回 0
+func PriorityFrom(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	if p, ok := ctx.Value(priorityKey{}).(int); ok {
+		return p
+	}
+	return 0
+}
+
+// PriorityExecutor 按优先级调度的执行器，固定数量的 worker 总是先执行优先级最高的任务
+// 启用老化 (aging) 后，任务每等待一个 aging 周期有效优先级提升 1，避免低优先级任务饿死
+type PriorityExecutor struct {
+	lifecycle
+	metrics
+	fixedPool
+	aging time.Duration
+	epoch time.Time // 计算老化分数的基准时间，避免 UnixNano 过大损失浮点精度
+
+	queue priorityQueue
+	seq   uint64
+}
+
+// NewPriorityExecutor 创建 workers 个 worker 的优先级执行器，aging <= 0 表示不启用老化
+func NewPriorityExecutor(workers int, aging time.Duration) *PriorityExecutor {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	e := &PriorityExecutor{
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "priority"},
+		aging:     aging,
+		epoch:     time.Now(),
+	}
+	e.start(&e.mu, workers, e.work)
+	return e
+}
+
+// Submit 以优先级 0 提交任务
+func (e *PriorityExecutor) Submit(task Runnable) {
+	_ = e.submit(context.Background(), 0, task)
+}
+
+// SubmitCtx 以 ctx 上的优先级 (WithPriority) 提交任务，开始前 ctx 已结束的任务会被丢弃
+func (e *PriorityExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	return e.submit(ctx, PriorityFrom(ctx), task)
+}
+
+// TrySubmit 队列无界，除关闭外不会拒绝，等同于 SubmitCtx
+func (e *PriorityExecutor) TrySubmit(ctx context.Context, task Runnable) error {
+	return e.SubmitCtx(ctx, task)
+}
+
+// SubmitPriority 以指定优先级提交任务
+func (e *PriorityExecutor) SubmitPriority(priority int, task Runnable) error {
+	return e.submit(context.Background(), priority, task)
+}
+
+func (e *PriorityExecutor) submit(ctx context.Context, priority int, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now()
+
+	e.mu.Lock()
+	if e.shutdown {
+		e.mu.Unlock()
+		e.rejected.Add(1)
+		return ErrShutdown
+	}
+	e.seq++
+	heap.Push(&e.queue, &priorityTask{
+		job:   job{ctx: ctx, task: task, enqueued: now},
+		score: e.score(priority, now),
+		seq:   e.seq,
+	})
+	e.mu.Unlock()
+
+	e.submitted.Add(1)
+	e.cond.Signal()
+	return nil
+}
+
+// score 有效优先级 = priority + 等待时间/aging
+// 所有任务以相同速率老化，因此可以换算成与当前时间无关的静态分数：priority - 入队时间/aging
+func (e *PriorityExecutor) score(priority int, enqueued time.Time) float64 {
+	if e.aging <= 0 {
+		return float64(priority)
+	}
+	return float64(priority) - float64(enqueued.Sub(e.epoch))/float64(e.aging)
+}
+
+// Shutdown 停止接受新任务，队列中的任务按优先级执行完后 worker 退出
+func (e *PriorityExecutor) Shutdown() {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	e.mu.Unlock()
+	e.cond.Broadcast()
+}
+
+// ShutdownNow 停止接受新任务，按优先级顺序返回尚未开始的任务
+func (e *PriorityExecutor) ShutdownNow() []Runnable {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	var jobs []job
+	for e.queue.Len() > 0 {
+		jobs = append(jobs, heap.Pop(&e.queue).(*priorityTask).job)
+	}
+	e.mu.Unlock()
+	e.cond.Broadcast()
+
+	e.rejected.Add(uint64(len(jobs)))
+	pending := make([]Runnable, 0, len(jobs))
+	for _, j := range jobs {
+		NotifyRejected(j.ctx, ErrShutdown)
+		pending = append(pending, j.task)
+	}
+	return pending
+}
+
+// Stats 返回运行指标
+func (e *PriorityExecutor) Stats() Stats {
+	s := e.snapshot()
+	e.mu.RLock()
+	s.Workers = e.alive
+	s.QueuedTasks = e.queue.Len()
+	e.mu.RUnlock()
+	return s
+}
+
+func (e *PriorityExecutor) work() {
+	for {
+		e.mu.Lock()
+		for {
+			if e.retireLocked() {
+				e.mu.Unlock()
+				return
+			}
+			if e.queue.Len() > 0 || e.shutdown {
+				break
+			}
+			e.cond.Wait()
+		}
+		if e.queue.Len() == 0 {
+			// 已关闭且队列为空
+			e.alive--
+			if e.alive == 0 {
+				e.terminate()
+			}
+			e.mu.Unlock()
+			return
+		}
+		j := heap.Pop(&e.queue).(*priorityTask).job
+		e.mu.Unlock()
+
+		if err := j.ctx.Err(); err != nil {
+			e.rejected.Add(1)
+			NotifyRejected(j.ctx, err)
+			continue
+		}
+		e.metrics.run(j.ctx, j.task, j.enqueued)
+	}
+}
+
+// ============ 优先队列 ============
+
+type priorityTask struct {
+	job
+	score float64
+	seq   uint64 // 分数相同时先进先出
+}
+
+type priorityQueue []*priorityTask
+
+func (q priorityQueue) Len() int { return len(q) }
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].score != q[j].score {
+		return q[i].score > q[j].score
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q priorityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue) Push(x any) { *q = append(*q, x.(*priorityTask)) }
+
+func (q *priorityQueue) Pop() any {
+	old := *q
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return t
+}
@@ -0,0 +1,109 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockPriority 占住唯一的 worker，返回释放函数
+func blockPriority(t *testing.T, e *PriorityExecutor) func() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e.Submit(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	return func() { close(release) }
+}
+
+func TestPriorityExecutor_Order(t *testing.T) {
+	e := NewPriorityExecutor(1, 0)
+	release := blockPriority(t, e)
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for _, p := range []int{1, 5, 3, 5, 0} {
+		p := p
+		wg.Add(1)
+		_ = e.SubmitCtx(WithPriority(context.Background(), p), func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+		})
+	}
+	release()
+	wg.Wait()
+
+	want := []int{5, 5, 3, 1, 0}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestPriorityExecutor_AgingPreventsStarvation(t *testing.T) {
+	e := NewPriorityExecutor(1, time.Millisecond)
+	release := blockPriority(t, e)
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	record := func(name string) Runnable {
+		wg.Add(1)
+		return func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+
+	_ = e.SubmitPriority(0, record("low"))
+	// 低优先级任务已等待 20 个老化周期，有效优先级超过稍后提交的 5
+	time.Sleep(20 * time.Millisecond)
+	_ = e.SubmitPriority(5, record("high"))
+	release()
+	wg.Wait()
+
+	if order[0] != "low" {
+		t.Errorf("Aged low-priority task should run first, got %v", order)
+	}
+}
+
+func TestPriorityExecutor_ShutdownNow(t *testing.T) {
+	e := NewPriorityExecutor(1, 0)
+	release := blockPriority(t, e)
+
+	var got []int
+	for _, p := range []int{1, 3, 2} {
+		p := p
+		_ = e.SubmitPriority(p, func() { got = append(got, p) })
+	}
+	pending := e.ShutdownNow()
+	release()
+
+	if len(pending) != 3 {
+		t.Fatalf("Expected 3 pending tasks, got %d", len(pending))
+	}
+	for _, task := range pending {
+		task()
+	}
+	if got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("Pending tasks should be returned in priority order, got %v", got)
+	}
+
+	if err := e.SubmitPriority(1, func() {}); err != ErrShutdown {
+		t.Errorf("Expected ErrShutdown, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := e.AwaitTermination(ctx); err != nil {
+		t.Fatalf("AwaitTermination failed: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWeightExceedsBurst 任务权重大于令牌桶容量，永远无法获得足够令牌
+var ErrWeightExceedsBurst = errors.New("pool: task weight exceeds rate limiter burst")
+
+// ContextExecutor 支持携带 context 提交任务的执行器
+// 任务开始前如果 ctx 已结束，执行器可以直接丢弃该任务而不执行
+type ContextExecutor interface {
+	Executor
+	SubmitCtx(ctx context.Context, task Runnable) error
+}
+
+// RateLimitedExecutor 基于令牌桶限制任务启动速率的执行器
+// 任务按提交顺序排队，拿到令牌后再交给底层执行器执行，排队期间 ctx 结束的任务会被丢弃。
+// 因 ctx 结束或关闭而丢弃的任务都会计入 Rejected 并通知拒绝回调（NotifyRejected），Submit 忽略返回值时提交者同样能感知。
+// 关闭只影响限速队列本身，所有任务交给底层执行器后即视为终止，底层执行器需要单独关闭
+type RateLimitedExecutor struct {
+	lifecycle
+	metrics
+	base  Executor
+	rate  float64 // 每秒生成的令牌数
+	burst float64 // 令牌桶容量
+
+	tokens float64
+	last   time.Time
+	queue  []rateTask
+	signal chan struct{}
+
+	dropped atomic.Uint64
+}
+
+type rateTask struct {
+	ctx    context.Context
+	weight float64
+	task   Runnable
+}
+
+// NewRateLimitedExecutor 创建限速执行器
+// rate 为每秒允许启动的任务权重，burst 为令牌桶容量（允许的突发量）
+func NewRateLimitedExecutor(base Executor, rate float64, burst int) *RateLimitedExecutor {
+	if base == nil {
+		panic("pool: base executor cannot be nil")
+	}
+	if rate <= 0 {
+		panic(fmt.Sprintf("pool: invalid rate %v", rate))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	e := &RateLimitedExecutor{
+		lifecycle: newLifecycle(),
+		base:      base,
+		rate:      rate,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      time.Now(),
+		signal:    make(chan struct{}, 1),
+	}
+	go e.dispatch()
+	return e
+}
+
+// Submit 以权重 1 提交任务
+func (e *RateLimitedExecutor) Submit(task Runnable) {
+	_ = e.SubmitWeighted(context.Background(), 1, task)
+}
+
+// SubmitCtx 以权重 1 提交任务，ctx 在任务开始前结束则丢弃任务
+func (e *RateLimitedExecutor) SubmitCtx(ctx context.Context, task Runnable) error {
+	return e.SubmitWeighted(ctx, 1, task)
+}
+
+// SubmitWeighted 提交一个消耗 weight 个令牌的任务
+func (e *RateLimitedExecutor) SubmitWeighted(ctx context.Context, weight int, task Runnable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if weight < 1 {
+		weight = 1
+	}
+	if float64(weight) > e.burst {
+		return ErrWeightExceedsBurst
+	}
+	if err := ctx.Err(); err != nil {
+		e.dropped.Add(1)
+		e.rejected.Add(1)
+		NotifyRejected(ctx, err)
+		return err
+	}
+
+	e.mu.Lock()
+	if e.shutdown {
+		e.mu.Unlock()
+		e.rejected.Add(1)
+		NotifyRejected(ctx, ErrShutdown)
+		return ErrShutdown
+	}
+	e.queue = append(e.queue, rateTask{ctx: ctx, weight: float64(weight), task: task})
+	e.mu.Unlock()
+	e.submitted.Add(1)
+
+	select {
+	case e.signal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Stats 返回限速队列的指标，任务交给底层执行器后的运行情况需查看底层执行器
+func (e *RateLimitedExecutor) Stats() Stats {
+	s := e.snapshot()
+	e.mu.RLock()
+	s.QueuedTasks = len(e.queue)
+	e.mu.RUnlock()
+	return s
+}
+
+// Dropped 返回因 ctx 结束而被丢弃的任务数
+func (e *RateLimitedExecutor) Dropped() uint64 {
+	return e.dropped.Load()
+}
+
+// Shutdown 停止接受新任务，队列中的任务仍按速率交给底层执行器
+func (e *RateLimitedExecutor) Shutdown() {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	e.mu.Unlock()
+}
+
+// ShutdownNow 停止接受新任务，返回尚未交给底层执行器的任务
+func (e *RateLimitedExecutor) ShutdownNow() []Runnable {
+	e.mu.Lock()
+	e.beginShutdownLocked()
+	queue := e.queue
+	e.queue = nil
+	e.mu.Unlock()
+
+	pending := make([]Runnable, 0, len(queue))
+	e.rejected.Add(uint64(len(queue)))
+	for _, t := range queue {
+		NotifyRejected(t.ctx, ErrShutdown)
+		pending = append(pending, t.task)
+	}
+	return pending
+}
+
+// dispatch 按 FIFO 顺序为队首任务等待令牌，关闭且队列清空后退出
+func (e *RateLimitedExecutor) dispatch() {
+	defer e.terminate()
+	for {
+		e.mu.Lock()
+		// 丢弃已取消的队首任务，它们不消耗令牌
+		var dropped []rateTask
+		for len(e.queue) > 0 && e.queue[0].ctx.Err() != nil {
+			dropped = append(dropped, e.queue[0])
+			e.popLocked()
+		}
+		if len(dropped) > 0 {
+			// 回调可能再次提交任务，解锁后再通知
+			e.mu.Unlock()
+			e.dropped.Add(uint64(len(dropped)))
+			e.rejected.Add(uint64(len(dropped)))
+			for _, t := range dropped {
+				NotifyRejected(t.ctx, t.ctx.Err())
+			}
+			continue
+		}
+		if len(e.queue) == 0 {
+			shutdown := e.shutdown
+			e.mu.Unlock()
+			if shutdown {
+				return
+			}
+			select {
+			case <-e.signal:
+			case <-e.quit:
+			}
+			continue
+		}
+
+		head := e.queue[0]
+		e.refillLocked(time.Now())
+		if e.tokens >= head.weight {
+			e.tokens -= head.weight
+			e.popLocked()
+			e.mu.Unlock()
+			e.submitBase(head)
+			continue
+		}
+		wait := time.Duration((head.weight - e.tokens) / e.rate * float64(time.Second))
+		// 已经关闭后 quit 不再作为唤醒条件，否则会空转
+		quit := e.quit
+		if e.shutdown {
+			quit = nil
+		}
+		e.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-head.ctx.Done():
+			timer.Stop()
+		case <-quit:
+			// ShutdownNow 可能已清空队列，重新检查
+			timer.Stop()
+		}
+	}
+}
+
+// submitBase 交给底层执行器，底层执行器直接返回的错误同样计入 Rejected 并通知拒绝回调
+func (e *RateLimitedExecutor) submitBase(t rateTask) {
+	ce, ok := e.base.(ContextExecutor)
+	if !ok {
+		e.base.Submit(t.task)
+		return
+	}
+	if err := ce.SubmitCtx(t.ctx, t.task); err != nil {
+		e.rejected.Add(1)
+		NotifyRejected(t.ctx, err)
+	}
+}
+
+func (e *RateLimitedExecutor) popLocked() {
+	e.queue[0] = rateTask{}
+	e.queue = e.queue[1:]
+}
+
+func (e *RateLimitedExecutor) refillLocked(now time.Time) {
+	elapsed := now.Sub(e.last).Seconds()
+	e.last = now
+	e.tokens += elapsed * e.rate
+	if e.tokens > e.burst {
+		e.tokens = e.burst
+	}
+}
+
+// SetPanicHandler 任务由底层执行器执行，底层执行器实现 PanicHandlerSetter 时转发给它
+func (e *RateLimitedExecutor) SetPanicHandler(h PanicHandler) {
+	if s, ok := e.base.(PanicHandlerSetter); ok {
+		s.SetPanicHandler(h)
+	}
+}
+
+// SetTiming 任务由底层执行器执行，底层执行器实现 TimingSetter 时转发给它
+func (e *RateLimitedExecutor) SetTiming(enabled bool) {
+	if s, ok := e.base.(TimingSetter); ok {
+		s.SetTiming(enabled)
+	}
+}
@@ -0,0 +1,140 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedExecutor_Rate(t *testing.T) {
+	// 每秒 100 个令牌，突发 1：10 个任务至少需要约 90ms
+	exec := NewRateLimitedExecutor(NewBlockingExecutor(4), 100, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(10)
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		exec.Submit(func() { wg.Done() })
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Rate limit not respected, 10 tasks finished in %v", elapsed)
+	}
+}
+
+func TestRateLimitedExecutor_Burst(t *testing.T) {
+	exec := NewRateLimitedExecutor(NewBlockingExecutor(8), 1, 5)
+
+	var wg sync.WaitGroup
+	wg.Add(5)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		exec.Submit(func() { wg.Done() })
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Burst should start immediately, took %v", elapsed)
+	}
+}
+
+func TestRateLimitedExecutor_DropsCancelled(t *testing.T) {
+	exec := NewRateLimitedExecutor(NewBlockingExecutor(2), 10, 1)
+
+	// 消耗掉唯一的令牌，后续任务需要排队约 100ms
+	exec.Submit(func() {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var ran int32
+	if err := exec.SubmitCtx(ctx, func() { atomic.StoreInt32(&ran, 1) }); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	done := make(chan struct{})
+	exec.Submit(func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Task after cancelled one never ran")
+	}
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Error("Cancelled task should be dropped")
+	}
+	if exec.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped task, got %d", exec.Dropped())
+	}
+}
+
+func TestRateLimitedExecutor_NotifiesRejected(t *testing.T) {
+	base := NewBlockingExecutorWithPolicy(2, BlockPolicy)
+	exec := NewRateLimitedExecutor(base, 10, 1)
+	exec.Submit(func() {})
+
+	// 排队期间 ctx 结束，dispatch 丢弃时通知
+	errs := make(chan error, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = WithRejectHandler(ctx, func(err error) { errs <- err })
+	if err := exec.SubmitCtx(ctx, func() {}); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	select {
+	case err := <-errs:
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Dropped task was not reported")
+	}
+
+	// 底层执行器已关闭，交给它时被拒绝
+	base.Shutdown()
+	notify := WithRejectHandler(context.Background(), func(err error) { errs <- err })
+	if err := exec.SubmitCtx(notify, func() {}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if err != ErrShutdown {
+			t.Errorf("Expected ErrShutdown from base, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Task rejected by base executor was not reported")
+	}
+
+	// 限速执行器自身关闭后提交
+	exec.Shutdown()
+	exec.Submit(func() {})
+	_ = exec.SubmitCtx(notify, func() {})
+	if err := <-errs; err != ErrShutdown {
+		t.Errorf("Expected ErrShutdown, got %v", err)
+	}
+	if s := exec.Stats(); s.Rejected != 4 {
+		t.Errorf("Expected 4 rejected tasks, got %d", s.Rejected)
+	}
+}
+
+func TestRateLimitedExecutor_Weight(t *testing.T) {
+	exec := NewRateLimitedExecutor(NewBlockingExecutor(2), 100, 3)
+
+	if err := exec.SubmitWeighted(context.Background(), 4, func() {}); err != ErrWeightExceedsBurst {
+		t.Fatalf("Expected ErrWeightExceedsBurst, got %v", err)
+	}
+
+	// 先用掉 3 个令牌，权重为 3 的任务需要等待约 30ms
+	var wg sync.WaitGroup
+	wg.Add(2)
+	start := time.Now()
+	_ = exec.SubmitWeighted(context.Background(), 3, func() { wg.Done() })
+	_ = exec.SubmitWeighted(context.Background(), 3, func() { wg.Done() })
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Weighted task should wait for tokens, took %v", elapsed)
+	}
+}
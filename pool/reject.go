@@ -0,0 +1,100 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRejected 执行器饱和时按拒绝策略拒绝或丢弃任务
+var ErrRejected = errors.New("pool: task rejected")
+
+// ExecutorService 能够报告提交失败的执行器
+type ExecutorService interface {
+	ContextExecutor
+	// TrySubmit 非阻塞提交，执行器饱和时按拒绝策略处理，被拒绝时返回 ErrRejected
+	TrySubmit(ctx context.Context, task Runnable) error
+}
+
+// RejectionPolicy 执行器饱和（队列已满且无法扩容）时的处理策略
+type RejectionPolicy int
+
+const (
+	// BlockPolicy 阻塞提交者直到有空位（默认），TrySubmit 时等同于 AbortPolicy
+	BlockPolicy RejectionPolicy = iota
+	// AbortPolicy 拒绝新任务，返回 ErrRejected
+	AbortPolicy
+	// CallerRunsPolicy 在提交者的 goroutine 中直接执行新任务
+	CallerRunsPolicy
+	// DiscardPolicy 静默丢弃新任务，提交者不会收到错误，但会触发任务的拒绝回调
+	DiscardPolicy
+	// DiscardOldestPolicy 丢弃队列中最老的任务（触发其拒绝回调），然后重新提交新任务
+	DiscardOldestPolicy
+)
+
+func (p RejectionPolicy) String() string {
+	switch p {
+	case BlockPolicy:
+		return "block"
+	case AbortPolicy:
+		return "abort"
+	case CallerRunsPolicy:
+		return "caller-runs"
+	case DiscardPolicy:
+		return "discard"
+	case DiscardOldestPolicy:
+		return "discard-oldest"
+	default:
+		return "unknown"
+	}
+}
+
+// RejectHandler 可由提交任务时使用的 context 实现，用于接收任务在执行前被丢弃的通知
+type RejectHandler interface {
+	OnRejected(err error)
+}
+
+type rejectHandlerKey struct{}
+
+// WithRejectHandler 返回携带拒绝回调的 context
+// 通过 SubmitCtx / TrySubmit 提交的任务如果在执行前被执行器丢弃（而不是直接向提交者返回错误），
+// 执行器会以丢弃原因调用该回调，例如 DiscardOldestPolicy 挤掉的任务
+func WithRejectHandler(ctx context.Context, fn func(error)) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, rejectHandlerKey{}, fn)
+}
+
+// NotifyRejected 通知任务的提交者任务已被丢弃，供执行器实现使用
+// ctx 本身实现了 RejectHandler 时直接调用，否则查找 WithRejectHandler 设置的回调
+func NotifyRejected(ctx context.Context, err error) {
+	if ctx == nil {
+		return
+	}
+	if h, ok := ctx.(RejectHandler); ok {
+		h.OnRejected(err)
+		return
+	}
+	if fn, ok := ctx.Value(rejectHandlerKey{}).(func(error)); ok && fn != nil {
+		fn(err)
+	}
+}
+
+// reject 按策略处理一个无法立即执行的任务，并记录指标
+// 返回值表示提交者看到的错误，DiscardOldestPolicy 需要执行器自行处理，这里按 AbortPolicy 兜底
+func (m *metrics) reject(policy RejectionPolicy, ctx context.Context, task Runnable) error {
+	switch policy {
+	case CallerRunsPolicy:
+		m.submitted.Add(1)
+		m.run(ctx, task, time.Now())
+		return nil
+	case DiscardPolicy:
+		m.rejected.Add(1)
+		NotifyRejected(ctx, ErrRejected)
+		return nil
+	default:
+		m.rejected.Add(1)
+		return ErrRejected
+	}
+}
@@ -0,0 +1,113 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// saturate 占满 1 个 worker 和容量为 1 的队列，返回释放函数
+func saturate(t *testing.T, p *WorkerPool) func() {
+	t.Helper()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := p.TrySubmit(context.Background(), func() { close(started); <-release }); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if err := p.TrySubmit(context.Background(), func() {}); err != nil {
+		t.Fatal(err)
+	}
+	return func() { close(release) }
+}
+
+func TestWorkerPool_AbortPolicy(t *testing.T) {
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{MinWorkers: 1, QueueSize: 1, Rejection: AbortPolicy})
+	release := saturate(t, p)
+	defer release()
+
+	if err := p.TrySubmit(context.Background(), func() {}); err != ErrRejected {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+	if err := p.SubmitCtx(context.Background(), func() {}); err != ErrRejected {
+		t.Fatalf("SubmitCtx should not block under AbortPolicy, got %v", err)
+	}
+}
+
+func TestWorkerPool_BlockPolicyTrySubmit(t *testing.T) {
+	p := NewWorkerPool(1, 1)
+	release := saturate(t, p)
+	defer release()
+
+	if err := p.TrySubmit(context.Background(), func() {}); err != ErrRejected {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.SubmitCtx(ctx, func() {}); err != context.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWorkerPool_CallerRunsPolicy(t *testing.T) {
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{MinWorkers: 1, QueueSize: 1, Rejection: CallerRunsPolicy})
+	release := saturate(t, p)
+	defer release()
+
+	var ran bool
+	if err := p.TrySubmit(context.Background(), func() { ran = true }); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Error("Task should run in the caller goroutine")
+	}
+}
+
+func TestWorkerPool_DiscardPolicies(t *testing.T) {
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{MinWorkers: 1, QueueSize: 1, Rejection: DiscardPolicy})
+	release := saturate(t, p)
+
+	var rejected int32
+	ctx := WithRejectHandler(context.Background(), func(err error) {
+		if err == ErrRejected {
+			atomic.AddInt32(&rejected, 1)
+		}
+	})
+	if err := p.TrySubmit(ctx, func() { t.Error("Discarded task should not run") }); err != nil {
+		t.Fatalf("DiscardPolicy should not report an error, got %v", err)
+	}
+	release()
+	if atomic.LoadInt32(&rejected) != 1 {
+		t.Error("Reject handler should be notified")
+	}
+
+	p2 := NewWorkerPoolWithConfig(WorkerPoolConfig{MinWorkers: 1, QueueSize: 1, Rejection: DiscardOldestPolicy})
+	release2 := make(chan struct{})
+	started := make(chan struct{})
+	_ = p2.TrySubmit(context.Background(), func() { close(started); <-release2 })
+	<-started
+	_ = p2.TrySubmit(ctx, func() { t.Error("Oldest task should be discarded") })
+
+	done := make(chan struct{})
+	if err := p2.TrySubmit(context.Background(), func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	close(release2)
+	<-done
+	if atomic.LoadInt32(&rejected) != 2 {
+		t.Error("Oldest task's reject handler should be notified")
+	}
+}
+
+func TestBlockingExecutor_TrySubmit(t *testing.T) {
+	exec := NewBlockingExecutorWithPolicy(1, AbortPolicy)
+	release := make(chan struct{})
+	exec.Submit(func() { <-release })
+	defer close(release)
+
+	if err := exec.TrySubmit(context.Background(), func() {}); err != ErrRejected {
+		t.Fatalf("Expected ErrRejected, got %v", err)
+	}
+}
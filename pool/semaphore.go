@@ -0,0 +1,203 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// semaphore 可在运行时调整容量的加权信号量，等待者按先进先出顺序获得许可
+// 缩容时已发放的许可不会被收回，占用量降到新容量以下后才会继续发放。
+// 超过容量的请求在没有任何许可被占用时发放，即独占整个信号量，避免永远无法满足而堵住后面的等待者。
+// 没有等待者时获取与释放只需一次 CAS，不加锁也不分配；排队的等待者从 waiterPool 复用，同样不分配
+type semaphore struct {
+	cur   atomic.Int64 // 已发放的许可数
+	max   atomic.Int64 // size + extra，供无锁路径读取
+	nwait atomic.Int32 // 排队中的等待者数，非 0 时新的获取者必须排队，保证先进先出
+
+	mu         sync.Mutex
+	closed     bool
+	size       int64
+	extra      int64 // 托管阻塞期间临时补偿的容量，不受 resize 影响
+	head, tail *semWaiter
+}
+
+type semWaiter struct {
+	n          int64
+	granted    bool          // 已获得许可，受 semaphore.mu 保护
+	err        error         // 未获得许可就被唤醒的原因，受 semaphore.mu 保护
+	ready      chan struct{} // 容量为 1，获得许可时发送
+	prev, next *semWaiter
+}
+
+var waiterPool = sync.Pool{
+	New: func() any { return &semWaiter{ready: make(chan struct{}, 1)} },
+}
+
+func newSemaphore(size int64) *semaphore {
+	s := &semaphore{size: size}
+	s.max.Store(size)
+	return s
+}
+
+// tryAcquire 非阻塞获取 n 个许可，有人排队时同样失败，保证先进先出
+func (s *semaphore) tryAcquire(n int64) bool {
+	for s.nwait.Load() == 0 {
+		c := s.cur.Load()
+		if !s.fits(c, n) {
+			return false
+		}
+		if s.cur.CompareAndSwap(c, c+n) {
+			return true
+		}
+	}
+	return false
+}
+
+// acquire 阻塞获取 n 个许可，ctx 结束返回 ctx.Err()，信号量关闭返回 ErrShutdown
+func (s *semaphore) acquire(ctx context.Context, n int64) error {
+	if s.tryAcquire(n) {
+		return nil
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrShutdown
+	}
+	w := waiterPool.Get().(*semWaiter)
+	w.n = n
+	s.push(w)
+	// 先登记再检查：与 release 的先归还再检查等待者配合，两者至少有一方能看到对方，不会丢失唤醒
+	s.nwait.Add(1)
+	s.notifyLocked()
+	s.mu.Unlock()
+
+	// 不可取消的 ctx 只需等待 ready，省去 select 的开销
+	done := ctx.Done()
+	if done == nil {
+		<-w.ready
+		return s.recycle(w)
+	}
+	select {
+	case <-w.ready:
+		return s.recycle(w)
+	case <-done:
+	}
+
+	s.mu.Lock()
+	if w.granted || w.err != nil {
+		// 放弃等待的同时已经拿到许可或信号量已关闭，以后者为准
+		s.mu.Unlock()
+		<-w.ready
+		return s.recycle(w)
+	}
+	front := s.head == w
+	s.remove(w)
+	// 队首的大任务离开后，后面的等待者可能已经能够获得许可
+	if front {
+		s.notifyLocked()
+	}
+	s.mu.Unlock()
+	s.recycle(w)
+	return ctx.Err()
+}
+
+func (s *semaphore) release(n int64) {
+	s.cur.Add(-n)
+	if s.nwait.Load() == 0 {
+		return
+	}
+	s.mu.Lock()
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+// close 唤醒所有等待者并返回 ErrShutdown，之后排队的获取者同样立即失败，已发放的许可照常归还
+func (s *semaphore) close() {
+	s.mu.Lock()
+	s.closed = true
+	for w := s.head; w != nil; w = s.head {
+		s.remove(w)
+		w.err = ErrShutdown
+		w.ready <- struct{}{}
+	}
+	s.mu.Unlock()
+}
+
+// resize 调整容量，扩容会立即唤醒能够获得许可的等待者
+func (s *semaphore) resize(size int64) {
+	s.mu.Lock()
+	s.size = size
+	s.max.Store(s.size + s.extra)
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+// compensate 临时增加（delta > 0）或收回（delta < 0）补偿容量
+func (s *semaphore) compensate(delta int64) {
+	s.mu.Lock()
+	s.extra += delta
+	s.max.Store(s.size + s.extra)
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+func (s *semaphore) fits(cur, n int64) bool {
+	return cur+n <= s.max.Load() || cur == 0
+}
+
+func (s *semaphore) limit() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// notifyLocked 按顺序向队首的等待者发放许可，队首无法满足时停止，避免大任务饿死
+func (s *semaphore) notifyLocked() {
+	for w := s.head; w != nil; w = s.head {
+		c := s.cur.Load()
+		if !s.fits(c, w.n) {
+			return
+		}
+		if !s.cur.CompareAndSwap(c, c+w.n) {
+			continue
+		}
+		s.remove(w)
+		w.granted = true
+		w.ready <- struct{}{}
+	}
+}
+
+func (s *semaphore) push(w *semWaiter) {
+	w.prev = s.tail
+	if s.tail != nil {
+		s.tail.next = w
+	} else {
+		s.head = w
+	}
+	s.tail = w
+}
+
+// remove 将 w 移出队列，调用方需持有 mu
+func (s *semaphore) remove(w *semWaiter) {
+	if w.prev != nil {
+		w.prev.next = w.next
+	} else {
+		s.head = w.next
+	}
+	if w.next != nil {
+		w.next.prev = w.prev
+	} else {
+		s.tail = w.prev
+	}
+	w.prev, w.next = nil, nil
+	s.nwait.Add(-1)
+}
+
+// recycle 归还已出队且 ready 已清空的等待者，返回它被唤醒的原因
+func (s *semaphore) recycle(w *semWaiter) error {
+	err := w.err
+	w.granted, w.err = false, nil
+	waiterPool.Put(w)
+	return err
+}
@@ -0,0 +1,158 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Stats 执行器运行指标快照
+type Stats struct {
+	// Workers 当前 worker（goroutine）数量，按任务启动 goroutine 的执行器等于 ActiveWorkers
+	Workers int
+	// ActiveWorkers 正在执行任务的数量
+	ActiveWorkers int
+	// QueuedTasks 已接受但尚未开始的任务数
+	QueuedTasks int
+	// Submitted 已接受的任务总数
+	Submitted uint64
+	// Completed 正常结束的任务总数
+	Completed uint64
+	// Panicked 发生 panic 的任务总数
+	Panicked uint64
+	// Rejected 被拒绝或丢弃的任务总数（拒绝策略、关闭、排队期间 ctx 结束）
+	Rejected uint64
+	// QueueWait 任务从提交到开始执行的等待时间分布
+	QueueWait Histogram
+	// RunTime 任务执行耗时分布
+	RunTime Histogram
+}
+
+// StatsProvider 能够提供运行指标的执行器
+type StatsProvider interface {
+	Stats() Stats
+}
+
+// TimingSetter 可以关闭耗时统计的执行器，内置执行器均已实现
+// 关闭后不再记录 QueueWait 与 RunTime，每个任务省去读取时钟的开销，计数器不受影响；
+// PanicInfo 的 Enqueued 与 Started 随之为零值
+type TimingSetter interface {
+	SetTiming(enabled bool)
+}
+
+// Histogram 直方图快照，Counts[i] 为耗时 <= Buckets[i] 的累计次数（与 Prometheus 的 le 语义一致）
+type Histogram struct {
+	Buckets []time.Duration
+	Counts  []uint64
+	Count   uint64
+	Sum     time.Duration
+}
+
+// histogramBuckets 直方图桶上界，覆盖 100µs ~ 10s
+var histogramBuckets = []time.Duration{
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// histogram 无锁直方图，counts 最后一位为 +Inf 桶
+type histogram struct {
+	counts [12]atomic.Uint64
+	sum    atomic.Int64
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := 0
+	for i < len(histogramBuckets) && d > histogramBuckets[i] {
+		i++
+	}
+	h.counts[i].Add(1)
+	h.sum.Add(int64(d))
+}
+
+func (h *histogram) snapshot() Histogram {
+	s := Histogram{
+		Buckets: histogramBuckets,
+		Counts:  make([]uint64, len(histogramBuckets)),
+		Sum:     time.Duration(h.sum.Load()),
+	}
+	var cumulative uint64
+	for i := range histogramBuckets {
+		cumulative += h.counts[i].Load()
+		s.Counts[i] = cumulative
+	}
+	s.Count = cumulative + h.counts[len(histogramBuckets)].Load()
+	return s
+}
+
+// metrics 内置执行器共用的计数器
+type metrics struct {
+	name         string // 执行器类型，用于 PanicInfo
+	panicHandler atomic.Pointer[PanicHandler]
+	submitted    atomic.Uint64
+	completed    atomic.Uint64
+	panicked     atomic.Uint64
+	rejected     atomic.Uint64
+	active       atomic.Int64
+	noTiming     atomic.Bool
+	queueWait    histogram
+	runTime      histogram
+}
+
+// run 执行任务并记录等待时间、执行耗时与 panic，返回任务是否 panic
+func (m *metrics) run(ctx context.Context, task Runnable, enqueued time.Time) (panicked bool) {
+	timing := !m.noTiming.Load()
+	var start time.Time
+	if timing {
+		start = time.Now()
+		m.queueWait.observe(start.Sub(enqueued))
+	}
+	m.active.Add(1)
+	panicked = m.runSafely(ctx, task, enqueued, start)
+	m.active.Add(-1)
+	if timing {
+		m.runTime.observe(time.Since(start))
+	}
+	if panicked {
+		m.panicked.Add(1)
+	} else {
+		m.completed.Add(1)
+	}
+	return panicked
+}
+
+// SetTiming 开启或关闭 QueueWait 与 RunTime 的统计，默认开启
+func (m *metrics) SetTiming(enabled bool) {
+	m.noTiming.Store(!enabled)
+}
+
+// now 返回任务的提交时间，关闭耗时统计时返回零值，不读取时钟
+func (m *metrics) now() time.Time {
+	if m.noTiming.Load() {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// snapshot 生成公共部分的快照，worker 数与队列长度由执行器填充
+func (m *metrics) snapshot() Stats {
+	active := int(m.active.Load())
+	return Stats{
+		Workers:       active,
+		ActiveWorkers: active,
+		Submitted:     m.submitted.Load(),
+		Completed:     m.completed.Load(),
+		Panicked:      m.panicked.Load(),
+		Rejected:      m.rejected.Load(),
+		QueueWait:     m.queueWait.snapshot(),
+		RunTime:       m.runTime.snapshot(),
+	}
+}
@@ -0,0 +1,152 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_Stats(t *testing.T) {
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{MinWorkers: 1, QueueSize: 1, Rejection: AbortPolicy})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	p.Submit(func() { close(started); <-release })
+	<-started
+	p.Submit(func() { panic("stats panic") })
+	_ = p.TrySubmit(context.Background(), func() {}) // 被拒绝
+
+	s := p.Stats()
+	if s.ActiveWorkers != 1 || s.QueuedTasks != 1 || s.Workers != 1 {
+		t.Errorf("Unexpected gauges: %+v", s)
+	}
+	if s.Submitted != 2 || s.Rejected != 1 {
+		t.Errorf("Expected 2 submitted and 1 rejected, got %d / %d", s.Submitted, s.Rejected)
+	}
+
+	close(release)
+	p.Shutdown()
+	_ = p.AwaitTermination(context.Background())
+
+	s = p.Stats()
+	if s.Completed != 1 || s.Panicked != 1 {
+		t.Errorf("Expected 1 completed and 1 panicked, got %d / %d", s.Completed, s.Panicked)
+	}
+	if s.RunTime.Count != 2 || s.QueueWait.Count != 2 {
+		t.Errorf("Histograms should observe 2 tasks, got %d / %d", s.RunTime.Count, s.QueueWait.Count)
+	}
+}
+
+func TestBlockingExecutor_Stats(t *testing.T) {
+	exec := NewBlockingExecutorWithPolicy(1, BlockPolicy)
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		exec.Submit(func() {
+			defer wg.Done()
+			time.Sleep(2 * time.Millisecond)
+		})
+	}
+	wg.Wait()
+	exec.Shutdown()
+	_ = exec.AwaitTermination(context.Background())
+
+	s := exec.(StatsProvider).Stats()
+	if s.Submitted != 3 || s.Completed != 3 {
+		t.Errorf("Expected 3 submitted and completed, got %+v", s)
+	}
+	// 第 2、3 个任务需要等待信号量
+	if s.QueueWait.Counts[0] == s.QueueWait.Count {
+		t.Error("Queue wait histogram should record waiting time")
+	}
+}
+
+func TestSetTiming(t *testing.T) {
+	exec := NewBlockingExecutorWithPolicy(2, BlockPolicy)
+	exec.(TimingSetter).SetTiming(false)
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		exec.Submit(wg.Done)
+	}
+	wg.Wait()
+	exec.Shutdown()
+	_ = exec.AwaitTermination(context.Background())
+
+	s := exec.(StatsProvider).Stats()
+	if s.Completed != 3 {
+		t.Errorf("Counters should not be affected, got %+v", s)
+	}
+	if s.RunTime.Count != 0 || s.QueueWait.Count != 0 {
+		t.Errorf("Histograms should be empty, got %d / %d", s.RunTime.Count, s.QueueWait.Count)
+	}
+}
+
+func TestHistogram_Snapshot(t *testing.T) {
+	var h histogram
+	h.observe(50 * time.Microsecond)
+	h.observe(3 * time.Millisecond)
+	h.observe(time.Minute)
+
+	s := h.snapshot()
+	if s.Count != 3 {
+		t.Fatalf("Expected count 3, got %d", s.Count)
+	}
+	if s.Counts[0] != 1 || s.Counts[3] != 2 || s.Counts[len(s.Counts)-1] != 2 {
+		t.Errorf("Unexpected cumulative counts: %v", s.Counts)
+	}
+	if s.Sum != 50*time.Microsecond+3*time.Millisecond+time.Minute {
+		t.Errorf("Unexpected sum: %v", s.Sum)
+	}
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	p := NewWorkerPool(1, 1)
+	done := make(chan struct{})
+	p.Submit(func() { close(done) })
+	<-done
+
+	rec := httptest.NewRecorder()
+	PrometheusHandler(map[string]StatsProvider{"io": p}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE gofuture_pool_submitted_total counter",
+		`gofuture_pool_submitted_total{executor="io"} 1`,
+		`gofuture_pool_run_seconds_bucket{executor="io",le="+Inf"} `,
+		`gofuture_pool_queue_wait_seconds_count{executor="io"} `,
+		`gofuture_pool_workers{executor="io"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Missing %q in output:\n%s", want, body)
+		}
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	p := NewWorkerPool(1, 1)
+	// 同名变量只能发布一次，-count > 1 时跳过重复发布
+	if expvar.Get("gofuture_test_pool") == nil {
+		PublishExpvar("gofuture_test_pool", p)
+	}
+
+	v := expvar.Get("gofuture_test_pool")
+	if v == nil {
+		t.Fatal("Expvar not published")
+	}
+	var s Stats
+	if err := json.Unmarshal([]byte(v.String()), &s); err != nil {
+		t.Fatalf("Invalid expvar JSON: %v", err)
+	}
+	if s.Workers != 1 {
+		t.Errorf("Expected 1 worker, got %d", s.Workers)
+	}
+	if len(s.RunTime.Buckets) == 0 {
+		t.Error("Histogram buckets should be exported")
+	}
+}
@@ -0,0 +1,52 @@
+package pool
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrWeightUnsupported 以大于 1 的权重提交到不支持权重的执行器
+var ErrWeightUnsupported = errors.New("pool: executor does not support weighted tasks")
+
+// WeightedExecutor 支持按权重提交任务的执行器
+// 权重表示任务占用的容量：blockingExecutor 中为并发名额数，RateLimitedExecutor 中为令牌数
+type WeightedExecutor interface {
+	Executor
+	SubmitWeighted(ctx context.Context, weight int, task Runnable) error
+}
+
+// Weighted 返回以固定权重提交任务的 Executor 视图，可直接传给 future 的 *WithExecutor 系列函数
+// exec 为 nil 时使用提交时的全局执行器。exec 未实现 WeightedExecutor 时，权重不超过 1 的任务按普通任务提交，
+// 更大的权重无法兑现，SubmitCtx 返回 ErrWeightUnsupported 而不是悄悄按权重 1 执行
+func Weighted(exec Executor, weight int) ContextExecutor {
+	return weightedView{exec: exec, weight: weight}
+}
+
+type weightedView struct {
+	exec   Executor
+	weight int
+}
+
+func (v weightedView) Submit(task Runnable) {
+	_ = v.SubmitCtx(context.Background(), task)
+}
+
+func (v weightedView) SubmitCtx(ctx context.Context, task Runnable) error {
+	exec := v.exec
+	if exec == nil {
+		exec = Global()
+	}
+	if e, ok := exec.(WeightedExecutor); ok {
+		return e.SubmitWeighted(ctx, v.weight, task)
+	}
+	if v.weight > 1 {
+		return ErrWeightUnsupported
+	}
+	switch e := exec.(type) {
+	case ContextExecutor:
+		return e.SubmitCtx(ctx, task)
+	default:
+		e.Submit(task)
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingExecutor_SubmitWeighted(t *testing.T) {
+	e := NewBlockingExecutorWithPolicy(4, AbortPolicy).(WeightedExecutor)
+
+	gate := make(chan struct{})
+	if err := e.SubmitWeighted(context.Background(), 3, func() { <-gate }); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.SubmitWeighted(context.Background(), 2, func() {}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("Expected ErrRejected with 3 of 4 permits taken, got %v", err)
+	}
+	done := make(chan struct{})
+	if err := e.SubmitWeighted(context.Background(), 1, func() { close(done) }); err != nil {
+		t.Fatalf("Expected the last permit to be available, got %v", err)
+	}
+	<-done
+	close(gate)
+}
+
+func TestBlockingExecutor_HeavyTaskNotStarved(t *testing.T) {
+	e := NewBlockingExecutor(2).(WeightedExecutor)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	gates := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	for _, g := range gates {
+		e.Submit(func() { <-g })
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = e.SubmitWeighted(context.Background(), 2, func() { record("heavy") })
+	}()
+	stats := e.(StatsProvider)
+	waitFor(t, func() bool { return stats.Stats().QueuedTasks == 1 }, "Heavy submitter not queued")
+
+	// 排在重任务之后的轻任务源源不断，空出的单个名额也不能被它们抢走
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.Submit(func() { record("light") })
+		}()
+	}
+	waitFor(t, func() bool { return stats.Stats().QueuedTasks == 6 }, "Light submitters not queued")
+
+	close(gates[0])
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	if len(order) != 0 {
+		t.Fatalf("Light task overtook the heavy one: %v", order)
+	}
+	mu.Unlock()
+
+	close(gates[1])
+	wg.Wait()
+	waitFor(t, func() bool { mu.Lock(); defer mu.Unlock(); return len(order) == 6 }, "Tasks did not finish")
+	if order[0] != "heavy" {
+		t.Fatalf("Expected heavy task first, got %v", order)
+	}
+}
+
+func TestBlockingExecutor_OversizedWeightRunsExclusively(t *testing.T) {
+	e := NewBlockingExecutor(2).(WeightedExecutor)
+
+	gate := make(chan struct{})
+	e.Submit(func() { <-gate })
+
+	done := make(chan struct{})
+	go func() {
+		_ = e.SubmitWeighted(context.Background(), 10, func() { close(done) })
+	}()
+	select {
+	case <-done:
+		t.Fatal("Oversized task must wait until the executor is idle")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(gate)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Oversized task never ran")
+	}
+}
+
+func TestWeighted_FallbackWithoutWeights(t *testing.T) {
+	ran := false
+	if err := Weighted(SyncExecutor{}, 1).SubmitCtx(context.Background(), func() { ran = true }); err != nil || !ran {
+		t.Fatalf("Expected task to run on a plain executor, ran=%v err=%v", ran, err)
+	}
+	// 权重无法兑现时报错，而不是悄悄按权重 1 执行
+	ran = false
+	if err := Weighted(SyncExecutor{}, 5).SubmitCtx(context.Background(), func() { ran = true }); !errors.Is(err, ErrWeightUnsupported) || ran {
+		t.Fatalf("Expected ErrWeightUnsupported, ran=%v err=%v", ran, err)
+	}
+
+	// Chain 转发权重
+	e := NewBlockingExecutorWithPolicy(2, AbortPolicy)
+	gate := make(chan struct{})
+	defer close(gate)
+	_ = Weighted(Chain(e), 2).SubmitCtx(context.Background(), func() { <-gate })
+	if err := e.TrySubmit(context.Background(), func() {}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("Expected weight forwarded through Chain, got %v", err)
+	}
+}
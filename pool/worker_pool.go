@@ -0,0 +1,407 @@
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerPoolConfig 工作池配置
+type WorkerPoolConfig struct {
+	// MinWorkers 常驻 worker 数量
+	MinWorkers int
+	// MaxWorkers 最大 worker 数量，小于 MinWorkers 时视为固定大小
+	MaxWorkers int
+	// QueueSize 有界任务队列容量，0 表示无缓冲（任务直接交给空闲 worker）
+	QueueSize int
+	// KeepAlive 超出 MinWorkers 的 worker 空闲多久后退出，默认 60s
+	KeepAlive time.Duration
+	// Rejection 队列已满且无法扩容时的拒绝策略，默认 BlockPolicy
+	Rejection RejectionPolicy
+	// PanicHandler 任务 panic 时的处理器，为 nil 时使用全局处理器 (SetPanicHandler)
+	PanicHandler PanicHandler
+}
+
+// WorkerPool 由常驻 worker 从有界队列中拉取任务执行的协程池
+// 与 blockingExecutor 每个任务启动一个新 goroutine 不同，worker 会被复用；
+// 没有空闲 worker 且未达到 MaxWorkers 时会临时扩容，队列满后按拒绝策略处理（默认阻塞，起到背压作用）
+type WorkerPool struct {
+	lifecycle
+	metrics
+	cfg   WorkerPoolConfig
+	tasks chan job
+
+	workers      atomic.Int32 // 当前 worker 数
+	idle         atomic.Int32 // 正在等待任务的 worker 数
+	minWorkers   atomic.Int32 // 可通过 SetMaxConcurrency 调整，因此不直接读取 cfg
+	maxWorkers   atomic.Int32
+	blockCounter // 得到补偿的托管阻塞 (spare) 不计入上限
+
+	submitters sync.WaitGroup // 正在提交中的调用，关闭时等待它们结束后再关闭队列
+	closed     atomic.Bool    // 队列已关闭
+}
+
+// job 队列中的任务，ctx 用于在执行前丢弃已取消的任务及通知拒绝回调
+type job struct {
+	ctx      context.Context
+	task     Runnable
+	enqueued time.Time
+}
+
+// NewWorkerPool 创建固定 workers 个 worker、队列容量为 queueSize 的工作池
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	return NewWorkerPoolWithConfig(WorkerPoolConfig{
+		MinWorkers: workers,
+		MaxWorkers: workers,
+		QueueSize:  queueSize,
+	})
+}
+
+// NewWorkerPoolWithConfig 根据配置创建工作池，MinWorkers 个 worker 会立即启动
+func NewWorkerPoolWithConfig(cfg WorkerPoolConfig) *WorkerPool {
+	if cfg.MinWorkers < 0 {
+		cfg.MinWorkers = 0
+	}
+	if cfg.MaxWorkers < cfg.MinWorkers {
+		cfg.MaxWorkers = cfg.MinWorkers
+	}
+	if cfg.MaxWorkers == 0 {
+		cfg.MaxWorkers = runtime.NumCPU()
+	}
+	if cfg.QueueSize < 0 {
+		cfg.QueueSize = 0
+	}
+	if cfg.KeepAlive <= 0 {
+		cfg.KeepAlive = 60 * time.Second
+	}
+
+	p := &WorkerPool{
+		lifecycle: newLifecycle(),
+		metrics:   metrics{name: "worker_pool"},
+		cfg:       cfg,
+		tasks:     make(chan job, cfg.QueueSize),
+	}
+	p.minWorkers.Store(int32(cfg.MinWorkers))
+	p.maxWorkers.Store(int32(cfg.MaxWorkers))
+	p.SetPanicHandler(cfg.PanicHandler)
+	for i := 0; i < cfg.MinWorkers; i++ {
+		p.workers.Add(1)
+		go p.work(job{})
+	}
+	return p
+}
+
+// Submit 提交任务，被拒绝时无法得知结果，需要感知拒绝请使用 SubmitCtx / TrySubmit
+func (p *WorkerPool) Submit(task Runnable) {
+	_ = p.SubmitCtx(context.Background(), task)
+}
+
+// SubmitCtx 提交任务，BlockPolicy 下队列满时阻塞直到有空位或 ctx 结束
+func (p *WorkerPool) SubmitCtx(ctx context.Context, task Runnable) error {
+	return p.submit(ctx, task, p.cfg.Rejection == BlockPolicy)
+}
+
+// TrySubmit 非阻塞提交，BlockPolicy 下队列满时直接返回 ErrRejected
+func (p *WorkerPool) TrySubmit(ctx context.Context, task Runnable) error {
+	return p.submit(ctx, task, false)
+}
+
+func (p *WorkerPool) submit(ctx context.Context, task Runnable, block bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p.mu.RLock()
+	if p.shutdown {
+		p.mu.RUnlock()
+		p.rejected.Add(1)
+		return ErrShutdown
+	}
+	p.submitters.Add(1)
+	p.mu.RUnlock()
+	defer p.submitters.Done()
+
+	j := job{ctx: ctx, task: task, enqueued: time.Now()}
+	if p.offer(j) {
+		return nil
+	}
+
+	// 队列已满：优先扩容直接执行
+	if p.trySpawn(j) {
+		p.submitted.Add(1)
+		return nil
+	}
+
+	if block {
+		select {
+		case p.tasks <- j:
+			p.submitted.Add(1)
+			return nil
+		case <-ctx.Done():
+			p.rejected.Add(1)
+			return ctx.Err()
+		case <-p.quit:
+			p.rejected.Add(1)
+			return ErrShutdown
+		}
+	}
+
+	if p.cfg.Rejection == DiscardOldestPolicy && p.cfg.QueueSize > 0 {
+		for {
+			select {
+			case old := <-p.tasks:
+				p.rejected.Add(1)
+				NotifyRejected(old.ctx, ErrRejected)
+			default:
+			}
+			if p.offer(j) {
+				return nil
+			}
+		}
+	}
+	return p.reject(p.cfg.Rejection, ctx, task)
+}
+
+// offer 非阻塞入队
+func (p *WorkerPool) offer(j job) bool {
+	select {
+	case p.tasks <- j:
+		p.submitted.Add(1)
+		// 入队成功，但没有空闲 worker 时尝试扩容，避免任务在队列中等待
+		if p.idle.Load() == 0 {
+			p.trySpawn(job{})
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown 停止接受新任务，队列中的任务执行完后 worker 退出
+func (p *WorkerPool) Shutdown() {
+	p.mu.Lock()
+	started := p.beginShutdownLocked()
+	p.mu.Unlock()
+	if !started {
+		return
+	}
+
+	// 等待正在提交的调用结束（阻塞中的提交者会因 quit 返回），之后才能安全关闭队列
+	go func() {
+		p.submitters.Wait()
+		p.closed.Store(true)
+		close(p.tasks)
+		p.onWorkerExit(p.workers.Load())
+	}()
+}
+
+// ShutdownNow 停止接受新任务，取出队列中尚未开始的任务并返回
+func (p *WorkerPool) ShutdownNow() []Runnable {
+	p.Shutdown()
+
+	var pending []Runnable
+	for {
+		select {
+		case j, ok := <-p.tasks:
+			if !ok {
+				return pending
+			}
+			if j.task == nil {
+				continue
+			}
+			p.rejected.Add(1)
+			NotifyRejected(j.ctx, ErrShutdown)
+			pending = append(pending, j.task)
+		default:
+			return pending
+		}
+	}
+}
+
+// Stats 返回运行指标
+func (p *WorkerPool) Stats() Stats {
+	s := p.snapshot()
+	s.Workers = p.Workers()
+	s.QueuedTasks = p.QueueLen()
+	return s
+}
+
+// Workers 返回当前 worker 数量
+func (p *WorkerPool) Workers() int {
+	return int(p.workers.Load())
+}
+
+// QueueLen 返回队列中等待执行的任务数
+func (p *WorkerPool) QueueLen() int {
+	return len(p.tasks)
+}
+
+// SetMaxConcurrency 运行时调整 worker 上限，n < 1 时按 1 处理，小于 MinWorkers 时 MinWorkers 随之下调
+// 多出的 worker 在完成当前任务或空闲时退出
+func (p *WorkerPool) SetMaxConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	p.maxWorkers.Store(int32(n))
+	if int(p.minWorkers.Load()) > n {
+		p.minWorkers.Store(int32(n))
+	}
+
+	// 向队列投递空任务唤醒阻塞中的空闲 worker，让它们检查是否需要退出
+	p.mu.RLock()
+	if p.shutdown {
+		p.mu.RUnlock()
+		return
+	}
+	p.submitters.Add(1)
+	p.mu.RUnlock()
+	defer p.submitters.Done()
+	for i := p.Workers() - n; i > 0; i-- {
+		select {
+		case p.tasks <- job{}:
+		default:
+			return
+		}
+	}
+}
+
+// MaxConcurrency 返回当前 worker 上限
+func (p *WorkerPool) MaxConcurrency() int {
+	return int(p.maxWorkers.Load())
+}
+
+// trySpawn 未达到 MaxWorkers 时启动一个新 worker，first 不为空时作为其第一个任务
+func (p *WorkerPool) trySpawn(first job) bool {
+	for {
+		n := p.workers.Load()
+		if n >= p.maxWorkers.Load()+p.spare.Load() {
+			return false
+		}
+		if p.workers.CompareAndSwap(n, n+1) {
+			go p.work(first)
+			return true
+		}
+	}
+}
+
+// tryRetire 空闲超时后尝试退出，保证至少保留 MinWorkers 个 worker
+func (p *WorkerPool) tryRetire() bool {
+	for {
+		n := p.workers.Load()
+		if n <= p.minWorkers.Load() {
+			return false
+		}
+		if p.workers.CompareAndSwap(n, n-1) {
+			p.onWorkerExit(n - 1)
+			return true
+		}
+	}
+}
+
+// retireExcess worker 数超过上限（SetMaxConcurrency 缩容）时退出
+func (p *WorkerPool) retireExcess() bool {
+	for {
+		n := p.workers.Load()
+		if n <= p.maxWorkers.Load()+p.spare.Load() {
+			return false
+		}
+		if p.workers.CompareAndSwap(n, n-1) {
+			p.onWorkerExit(n - 1)
+			return true
+		}
+	}
+}
+
+// beginBlocking 托管阻塞期间上限临时加一并立即补充一个 worker
+func (p *WorkerPool) beginBlocking() (int, bool) {
+	n, ok := p.enter(true)
+	if ok {
+		p.trySpawn(job{})
+	}
+	return n, ok
+}
+
+// endBlocking 收回补偿的名额，多出的 worker 在完成当前任务后退出
+func (p *WorkerPool) endBlocking(compensated bool) {
+	p.exit(compensated)
+}
+
+// onWorkerExit worker 退出后调用，n 为退出后的 worker 数
+func (p *WorkerPool) onWorkerExit(n int32) {
+	if n != 0 {
+		return
+	}
+	// 最后一个 worker 退出的同时有任务入队，补充一个 worker 避免任务滞留
+	if len(p.tasks) > 0 {
+		p.trySpawn(job{})
+		return
+	}
+	if p.closed.Load() {
+		p.terminate()
+	}
+}
+
+func (p *WorkerPool) work(first job) {
+	p.runJob(first)
+
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		if p.retireExcess() {
+			return
+		}
+		p.idle.Add(1)
+		// 固定大小的池不需要空闲计时器
+		if p.maxWorkers.Load() <= p.minWorkers.Load() {
+			j, ok := <-p.tasks
+			p.idle.Add(-1)
+			if !ok {
+				p.onWorkerExit(p.workers.Add(-1))
+				return
+			}
+			p.runJob(j)
+			continue
+		}
+
+		// Go 1.23 起 Reset 会丢弃未读取的过期信号，无需手动排空
+		if timer == nil {
+			timer = time.NewTimer(p.cfg.KeepAlive)
+		} else {
+			timer.Reset(p.cfg.KeepAlive)
+		}
+		select {
+		case j, ok := <-p.tasks:
+			p.idle.Add(-1)
+			if !ok {
+				p.onWorkerExit(p.workers.Add(-1))
+				return
+			}
+			p.runJob(j)
+		case <-timer.C:
+			p.idle.Add(-1)
+			if p.tryRetire() {
+				return
+			}
+		}
+	}
+}
+
+// runJob 执行任务，排队期间 ctx 已结束的任务直接丢弃
+func (p *WorkerPool) runJob(j job) {
+	if j.task == nil {
+		return
+	}
+	if err := j.ctx.Err(); err != nil {
+		p.rejected.Add(1)
+		NotifyRejected(j.ctx, err)
+		return
+	}
+	p.metrics.run(j.ctx, j.task, j.enqueued)
+}
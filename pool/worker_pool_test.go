@@ -0,0 +1,108 @@
+package pool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_FixedWorkers(t *testing.T) {
+	p := NewWorkerPool(3, 10)
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	wg.Add(20)
+	for i := 0; i < 20; i++ {
+		p.Submit(func() {
+			defer wg.Done()
+			cur := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&maxRunning)
+				if cur <= old || atomic.CompareAndSwapInt32(&maxRunning, old, cur) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	wg.Wait()
+
+	if maxRunning > 3 {
+		t.Errorf("Pool exceeded worker count. Max Running: %d", maxRunning)
+	}
+	if p.Workers() != 3 {
+		t.Errorf("Expected 3 workers, got %d", p.Workers())
+	}
+}
+
+func TestWorkerPool_ElasticKeepAlive(t *testing.T) {
+	p := NewWorkerPoolWithConfig(WorkerPoolConfig{
+		MinWorkers: 1,
+		MaxWorkers: 4,
+		QueueSize:  1,
+		KeepAlive:  20 * time.Millisecond,
+	})
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		p.Submit(func() {
+			defer wg.Done()
+			<-release
+		})
+	}
+	if p.Workers() < 2 {
+		t.Errorf("Pool should scale up under load, workers: %d", p.Workers())
+	}
+	close(release)
+	wg.Wait()
+
+	deadline := time.Now().Add(time.Second)
+	for p.Workers() > 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if p.Workers() != 1 {
+		t.Errorf("Idle workers should expire down to MinWorkers, got %d", p.Workers())
+	}
+}
+
+func TestWorkerPool_Backpressure(t *testing.T) {
+	p := NewWorkerPool(1, 1)
+	release := make(chan struct{})
+	p.Submit(func() { <-release }) // 占住 worker
+	p.Submit(func() {})            // 占满队列
+
+	submitted := make(chan struct{})
+	go func() {
+		p.Submit(func() {})
+		close(submitted)
+	}()
+
+	select {
+	case <-submitted:
+		t.Fatal("Submit should block while the queue is full")
+	case <-time.After(30 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-submitted:
+	case <-time.After(time.Second):
+		t.Fatal("Submit should resume after the queue drains")
+	}
+}
+
+func TestWorkerPool_PanicSafety(t *testing.T) {
+	p := NewWorkerPool(1, 0)
+	done := make(chan struct{})
+	p.Submit(func() { panic("worker panic") })
+	p.Submit(func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Worker died after panic")
+	}
+}